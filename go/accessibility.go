@@ -0,0 +1,50 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxDescribePayloadRunes caps how much of a decoded payload Describe
+// quotes verbatim, so a page's worth of text doesn't end up in an alt
+// attribute or a PDF tag.
+const maxDescribePayloadRunes = 80
+
+// Describe returns a short, human-readable description of q suitable for
+// an HTML alt attribute or PDF tagged-content ActualText, e.g. "QR code
+// linking to https://example.com, version 5, medium error correction".
+//
+// If q's payload cannot be recovered as text (see DecodeText, e.g. because
+// it was encoded as raw binary data or an unsupported ECI), the payload is
+// omitted rather than causing Describe to fail: "QR code, version 5,
+// medium error correction".
+func (q *QrCode) Describe() string {
+	var subject string
+	if text, err := q.DecodeText(); err == nil {
+		subject = fmt.Sprintf(" %s", describePayload(text))
+	}
+	return fmt.Sprintf("QR code%s, version %d, %s error correction",
+		subject, q.Version(), strings.ToLower(q.ErrorCorrectionLevel().String()))
+}
+
+// describePayload phrases text as either "linking to <url>" or "encoding
+// the text \"<text>\"", truncating text to maxDescribePayloadRunes runes
+// with an ellipsis if it's longer.
+func describePayload(text string) string {
+	runes := []rune(text)
+	truncated := len(runes) > maxDescribePayloadRunes
+	if truncated {
+		text = string(runes[:maxDescribePayloadRunes]) + "…"
+	}
+	if strings.HasPrefix(text, "http://") || strings.HasPrefix(text, "https://") {
+		return fmt.Sprintf("linking to %s", text)
+	}
+	return fmt.Sprintf("encoding the text %q", text)
+}
@@ -0,0 +1,94 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Package base45 implements the Base45 encoding defined by RFC 9285. Its
+// output alphabet is exactly the QR Code standard's alphanumeric-mode
+// charset, so Base45-encoded text always packs into the denser
+// alphanumeric mode instead of byte mode; this is the encoding the EU
+// Digital COVID Certificate ("HC1:") scheme relies on.
+package base45
+
+import (
+	"errors"
+	"fmt"
+)
+
+// charset is the 45-character alphabet RFC 9285 assigns to Base45, in
+// value order. It is identical to the QR Code standard's alphanumeric-mode
+// charset by design.
+const charset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// Encode returns the Base45 encoding of data, per RFC 9285: each pair of
+// input bytes becomes 3 output characters, and a trailing odd byte becomes
+// 2.
+func Encode(data []byte) string {
+	out := make([]byte, 0, (len(data)/2)*3+(len(data)%2)*2)
+	for i := 0; i+1 < len(data); i += 2 {
+		value := int(data[i])<<8 | int(data[i+1])
+		out = append(out, charset[value%45])
+		value /= 45
+		out = append(out, charset[value%45])
+		value /= 45
+		out = append(out, charset[value])
+	}
+	if len(data)%2 == 1 {
+		value := int(data[len(data)-1])
+		out = append(out, charset[value%45])
+		out = append(out, charset[value/45])
+	}
+	return string(out)
+}
+
+// Decode reverses Encode.
+//
+// Returns an error if s contains a character outside charset, has a final
+// group of other than 2 or 3 characters, or any 3-character group decodes
+// to a value greater than 65535 (not a valid pair of bytes).
+func Decode(s string) ([]byte, error) {
+	values := make([]int, len(s))
+	for i, c := range s {
+		v := indexInCharset(byte(c))
+		if c > 0x7F || v < 0 {
+			return nil, fmt.Errorf("base45: character %q at position %d is not in the Base45 alphabet", c, i)
+		}
+		values[i] = v
+	}
+
+	out := make([]byte, 0, (len(values)/3)*2+len(values)%3)
+	i := 0
+	for ; i+3 <= len(values); i += 3 {
+		value := values[i] + values[i+1]*45 + values[i+2]*45*45
+		if value > 0xFFFF {
+			return nil, fmt.Errorf("base45: group at position %d decodes to %d, greater than 65535", i, value)
+		}
+		out = append(out, byte(value>>8), byte(value))
+	}
+	switch len(values) - i {
+	case 0:
+		// Nothing left over.
+	case 2:
+		value := values[i] + values[i+1]*45
+		if value > 0xFF {
+			return nil, fmt.Errorf("base45: final group decodes to %d, greater than 255", value)
+		}
+		out = append(out, byte(value))
+	default:
+		return nil, errors.New("base45: input length leaves a final group of 1 character, which is invalid")
+	}
+	return out, nil
+}
+
+// indexInCharset returns c's value in charset, or -1 if c isn't a Base45
+// character.
+func indexInCharset(c byte) int {
+	for i := 0; i < len(charset); i++ {
+		if charset[i] == c {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,115 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package base45
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestEncodeRFC9285Vectors checks Encode against the worked examples in
+// RFC 9285 section 4.
+func TestEncodeRFC9285Vectors(t *testing.T) {
+	tests := []struct {
+		data []byte
+		want string
+	}{
+		{[]byte("AB"), "BB8"},
+		{[]byte("Hello!!"), "%69 VD92EX0"},
+		{[]byte("base-45"), "UJCLQE7W581"},
+	}
+	for _, test := range tests {
+		if got := Encode(test.data); got != test.want {
+			t.Errorf("Encode(%q) = %q, want %q", test.data, got, test.want)
+		}
+	}
+}
+
+// TestDecodeRFC9285Vectors checks Decode against the same worked examples,
+// in the opposite direction.
+func TestDecodeRFC9285Vectors(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []byte
+	}{
+		{"BB8", []byte("AB")},
+		{"%69 VD92EX0", []byte("Hello!!")},
+		{"UJCLQE7W581", []byte("base-45")},
+	}
+	for _, test := range tests {
+		got, err := Decode(test.s)
+		if err != nil {
+			t.Fatalf("Decode(%q) returned an error: %v", test.s, err)
+		}
+		if !bytes.Equal(got, test.want) {
+			t.Errorf("Decode(%q) = %q, want %q", test.s, got, test.want)
+		}
+	}
+}
+
+// TestRoundTrip checks that Decode(Encode(data)) == data for every length
+// from 0 to a few bytes past one full encoding group, covering the
+// even-length, odd-length, and empty cases.
+func TestRoundTrip(t *testing.T) {
+	for n := 0; n <= 8; n++ {
+		data := make([]byte, n)
+		for i := range data {
+			data[i] = byte(i*37 + 11)
+		}
+		encoded := Encode(data)
+		decoded, err := Decode(encoded)
+		if err != nil {
+			t.Fatalf("Decode(Encode(%v)) returned an error: %v", data, err)
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Errorf("Decode(Encode(%v)) = %v, want %v", data, decoded, data)
+		}
+	}
+}
+
+// TestEncodeOutputIsAlphanumeric checks that every character Encode
+// produces is in the QR Code standard's alphanumeric-mode charset, the
+// property that lets Base45 text pack into alphanumeric mode.
+func TestEncodeOutputIsAlphanumeric(t *testing.T) {
+	const qrAlphanumericCharset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+	data := []byte("the quick brown fox jumps over the lazy dog 0123456789")
+	for _, c := range Encode(data) {
+		if !bytes.ContainsRune([]byte(qrAlphanumericCharset), c) {
+			t.Errorf("Encode output contains %q, not in the QR alphanumeric charset", c)
+		}
+	}
+}
+
+// TestDecodeRejectsInvalidCharacter checks that Decode reports an error,
+// rather than panicking or silently skipping, on a character outside the
+// Base45 alphabet, including a non-ASCII rune.
+func TestDecodeRejectsInvalidCharacter(t *testing.T) {
+	for _, s := range []string{"a", "BB8a", "日B8"} {
+		if _, err := Decode(s); err == nil {
+			t.Errorf("Decode(%q) returned no error", s)
+		}
+	}
+}
+
+// TestDecodeRejectsBadFinalGroupLength checks that Decode rejects a final
+// group of 1 leftover character, which Encode never produces and which
+// cannot represent a whole number of bytes.
+func TestDecodeRejectsBadFinalGroupLength(t *testing.T) {
+	if _, err := Decode("BB8B"); err == nil {
+		t.Error("Decode of a 4-character string (final group of 1) returned no error")
+	}
+}
+
+// TestDecodeRejectsOverflowingGroup checks that Decode rejects a
+// 3-character group whose value exceeds 65535, which cannot represent a
+// valid pair of bytes.
+func TestDecodeRejectsOverflowingGroup(t *testing.T) {
+	if _, err := Decode(":::"); err == nil {
+		t.Error(`Decode(":::") (the maximum group value, 91124) returned no error`)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package base45
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// hc1Prefix is the context identifier the EU Digital COVID Certificate
+// scheme requires at the start of the QR Code payload.
+const hc1Prefix = "HC1:"
+
+// EncodeHC1 zlib-deflates data, Base45-encodes the result, and prepends
+// "HC1:", matching the pipeline the EU Digital COVID Certificate scheme
+// uses to turn a CBOR Web Token payload into QR Code text. Callers
+// otherwise assemble this same three-step pipeline by hand.
+func EncodeHC1(data []byte) (string, error) {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return hc1Prefix + Encode(buf.Bytes()), nil
+}
+
+// DecodeHC1 reverses EncodeHC1: it strips the "HC1:" prefix, Base45-decodes
+// the remainder, and zlib-inflates the result.
+//
+// Returns an error if s does not start with "HC1:", is not valid Base45, or
+// does not inflate to a complete zlib stream.
+func DecodeHC1(s string) ([]byte, error) {
+	rest, ok := strings.CutPrefix(s, hc1Prefix)
+	if !ok {
+		return nil, fmt.Errorf("base45: input does not start with %q", hc1Prefix)
+	}
+	compressed, err := Decode(rest)
+	if err != nil {
+		return nil, err
+	}
+	r, err := zlib.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
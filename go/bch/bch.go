@@ -0,0 +1,89 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Package bch computes and error-corrects the two fixed BCH codes used by
+// the QR Code format to protect its format bits (BCH(15,5)) and version
+// bits (BCH(18,6)), so that tooling and a future decoder can reuse this
+// polynomial math instead of reimplementing it.
+package bch
+
+import "math/bits"
+
+// FormatEncode returns the 15-bit BCH(15,5) codeword, with the fixed mask
+// XORed in, for the given 5-bit format data (the 2-bit error correction
+// level indicator followed by the 3-bit mask number).
+//
+// Panics if data is outside [0, 31].
+func FormatEncode(data uint32) uint32 {
+	if data > 0x1F {
+		panic("data out of range")
+	}
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412 // uint15
+}
+
+// FormatDecode finds the 5-bit format data whose FormatEncode output is
+// closest (in Hamming distance) to the given 15-bit observed value, and
+// reports whether it was within the 3-bit error correcting capacity of
+// BCH(15,5). If ok is false, data is the best guess but should not be
+// trusted.
+//
+// Panics if observed is outside [0, 0x7FFF].
+func FormatDecode(observed uint32) (data uint32, ok bool) {
+	if observed > 0x7FFF {
+		panic("value out of range")
+	}
+	bestDist := 999
+	for d := uint32(0); d < 32; d++ {
+		dist := bits.OnesCount32(FormatEncode(d) ^ observed)
+		if dist < bestDist {
+			bestDist = dist
+			data = d
+		}
+	}
+	return data, bestDist <= 3
+}
+
+// VersionEncode returns the 18-bit BCH(18,6) codeword for the given 6-bit
+// version data (a QR Code version number in the range [7, 40]).
+//
+// Panics if data is outside [0, 63].
+func VersionEncode(data uint32) uint32 {
+	if data > 0x3F {
+		panic("data out of range")
+	}
+	rem := data
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	return data<<12 | rem // uint18
+}
+
+// VersionDecode finds the 6-bit version data whose VersionEncode output is
+// closest (in Hamming distance) to the given 18-bit observed value, and
+// reports whether it was within the 3-bit error correcting capacity of
+// BCH(18,6). If ok is false, data is the best guess but should not be
+// trusted.
+//
+// Panics if observed is outside [0, 0x3FFFF].
+func VersionDecode(observed uint32) (data uint32, ok bool) {
+	if observed > 0x3FFFF {
+		panic("value out of range")
+	}
+	bestDist := 999
+	for d := uint32(0); d < 64; d++ {
+		dist := bits.OnesCount32(VersionEncode(d) ^ observed)
+		if dist < bestDist {
+			bestDist = dist
+			data = d
+		}
+	}
+	return data, bestDist <= 3
+}
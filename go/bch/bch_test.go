@@ -0,0 +1,143 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package bch
+
+import "testing"
+
+// TestFormatRoundTrip checks that every valid 5-bit format data value
+// encodes to a 15-bit codeword that FormatDecode reports as uncorrupted and
+// recovers exactly.
+func TestFormatRoundTrip(t *testing.T) {
+	for data := uint32(0); data <= 0x1F; data++ {
+		codeword := FormatEncode(data)
+		if codeword > 0x7FFF {
+			t.Fatalf("FormatEncode(%d) = %#x, out of the 15-bit range", data, codeword)
+		}
+		got, ok := FormatDecode(codeword)
+		if !ok {
+			t.Errorf("FormatDecode(FormatEncode(%d)) reported ok=false for an uncorrupted codeword", data)
+		}
+		if got != data {
+			t.Errorf("FormatDecode(FormatEncode(%d)) = %d, want %d", data, got, data)
+		}
+	}
+}
+
+// TestFormatCorrectsUpTo3BitErrors checks that BCH(15,5)'s documented 3-bit
+// error correcting capacity holds: flipping any 1, 2, or 3 bits of a
+// codeword still decodes to the original data.
+func TestFormatCorrectsUpTo3BitErrors(t *testing.T) {
+	const data = 0x0D // arbitrary in-range value
+	codeword := FormatEncode(data)
+	for mask := uint32(1); mask <= 0x7FFF; mask++ {
+		if popcount(mask) > 3 {
+			continue
+		}
+		corrupted := codeword ^ mask
+		got, ok := FormatDecode(corrupted)
+		if !ok {
+			t.Fatalf("FormatDecode(codeword ^ %#x) reported ok=false for a %d-bit error", mask, popcount(mask))
+		}
+		if got != data {
+			t.Fatalf("FormatDecode(codeword ^ %#x) = %d, want %d (a %d-bit error)", mask, got, data, popcount(mask))
+		}
+	}
+}
+
+// TestFormatEncodePanicsOutOfRange checks that FormatEncode rejects data
+// wider than 5 bits instead of silently truncating it.
+func TestFormatEncodePanicsOutOfRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("FormatEncode(0x20) did not panic")
+		}
+	}()
+	FormatEncode(0x20)
+}
+
+// TestFormatDecodePanicsOutOfRange checks that FormatDecode rejects an
+// observed value wider than 15 bits instead of silently truncating it.
+func TestFormatDecodePanicsOutOfRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("FormatDecode(0x8000) did not panic")
+		}
+	}()
+	FormatDecode(0x8000)
+}
+
+// TestVersionRoundTrip checks that every valid 6-bit version data value
+// encodes to an 18-bit codeword that VersionDecode reports as uncorrupted
+// and recovers exactly.
+func TestVersionRoundTrip(t *testing.T) {
+	for data := uint32(0); data <= 0x3F; data++ {
+		codeword := VersionEncode(data)
+		if codeword > 0x3FFFF {
+			t.Fatalf("VersionEncode(%d) = %#x, out of the 18-bit range", data, codeword)
+		}
+		got, ok := VersionDecode(codeword)
+		if !ok {
+			t.Errorf("VersionDecode(VersionEncode(%d)) reported ok=false for an uncorrupted codeword", data)
+		}
+		if got != data {
+			t.Errorf("VersionDecode(VersionEncode(%d)) = %d, want %d", data, got, data)
+		}
+	}
+}
+
+// TestVersionCorrectsUpTo3BitErrors checks that BCH(18,6)'s documented 3-bit
+// error correcting capacity holds: flipping any 1, 2, or 3 bits of a
+// codeword still decodes to the original data.
+func TestVersionCorrectsUpTo3BitErrors(t *testing.T) {
+	const data = 0x29 // arbitrary in-range 6-bit value (41, not itself a valid QR version)
+	codeword := VersionEncode(data)
+	for mask := uint32(1); mask <= 0x3FFFF; mask++ {
+		if popcount(mask) > 3 {
+			continue
+		}
+		corrupted := codeword ^ mask
+		got, ok := VersionDecode(corrupted)
+		if !ok {
+			t.Fatalf("VersionDecode(codeword ^ %#x) reported ok=false for a %d-bit error", mask, popcount(mask))
+		}
+		if got != data {
+			t.Fatalf("VersionDecode(codeword ^ %#x) = %d, want %d (a %d-bit error)", mask, got, data, popcount(mask))
+		}
+	}
+}
+
+// TestVersionEncodePanicsOutOfRange checks that VersionEncode rejects data
+// wider than 6 bits instead of silently truncating it.
+func TestVersionEncodePanicsOutOfRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("VersionEncode(0x40) did not panic")
+		}
+	}()
+	VersionEncode(0x40)
+}
+
+// TestVersionDecodePanicsOutOfRange checks that VersionDecode rejects an
+// observed value wider than 18 bits instead of silently truncating it.
+func TestVersionDecodePanicsOutOfRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("VersionDecode(0x40000) did not panic")
+		}
+	}()
+	VersionDecode(0x40000)
+}
+
+func popcount(x uint32) int {
+	n := 0
+	for x != 0 {
+		n += int(x & 1)
+		x >>= 1
+	}
+	return n
+}
@@ -0,0 +1,38 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+// BitBuffer is an appendable sequence of bits (0s and 1s).
+//
+// Mainly used to assemble the data codeword stream for a QrSegment list.
+type BitBuffer struct {
+	bits []bool
+}
+
+// Len returns the number of bits currently in the buffer.
+func (bb *BitBuffer) Len() int {
+	return len(bb.bits)
+}
+
+// AppendBits appends the given number of low-order bits of the given value
+// to this buffer.
+//
+// Requires length <= 31 and val < 2^length.
+func (bb *BitBuffer) AppendBits(val uint32, length int) {
+	if length < 0 || length > 31 || (val>>uint(length)) != 0 {
+		panic("value out of range")
+	}
+	for i := length - 1; i >= 0; i-- {
+		bb.bits = append(bb.bits, getBit(val, i))
+	}
+}
+
+// getBit reports whether the i'th bit of x (0 = lowest) is set to 1.
+func getBit(x uint32, i int) bool {
+	return (x>>uint(i))&1 != 0
+}
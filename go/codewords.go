@@ -0,0 +1,104 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+// AllCodewords reverses the zigzag module placement performed by the
+// constructor, returning this symbol's raw codeword bytes: the
+// error-correction-coded data, still interleaved across Reed-Solomon
+// blocks, in the exact order drawn onto the grid. Its length equals
+// getNumRawDataModules(q.Version()) / 8.
+//
+// This is a low-level method for decoders and for researchers inspecting
+// how data maps onto modules. Most callers that want the original payload
+// should use DataCodewords instead.
+func (q *QrCode) AllCodewords() []byte {
+	rawCodewords := getNumRawDataModules(q.version) / 8
+	result := make([]byte, rawCodewords)
+	predicate := q.mask.Func()
+
+	i := 0 // Bit index into the result
+	// Undo the funny zigzag scan performed by drawCodewords
+	for right := q.size - 1; right >= 1; right -= 2 { // Index of right column in each column pair
+		if right == 6 {
+			right = 5
+		}
+		for vert := int32(0); vert < q.size; vert++ { // Vertical counter
+			for j := int32(0); j < 2; j++ {
+				x := right - j // Actual x coordinate
+				upward := (right+1)&2 == 0
+				var y int32
+				if upward {
+					y = q.size - 1 - vert
+				} else {
+					y = vert
+				}
+				if !q.isFunction[y*q.size+x] && i < len(result)*8 {
+					bit := q.module(x, y)
+					if predicate(x, y) {
+						bit = !bit
+					}
+					if bit {
+						result[i>>3] |= 1 << uint(7-(i&7))
+					}
+					i++
+				}
+			}
+		}
+	}
+	return result
+}
+
+// DataCodewords reverses the Reed-Solomon block interleaving performed by
+// the constructor, returning this symbol's original data codeword bytes
+// with the per-block error correction codewords stripped out.
+//
+// The result equals the bytes assembleDataCodewords produced: decoding them
+// as mode/character-count/data bit fields (as EncodeSegmentsAdvanced would
+// have assembled them) recovers the segments that were encoded, assuming
+// no errors need correcting.
+func (q *QrCode) DataCodewords() []byte {
+	allCodewords := q.AllCodewords()
+
+	ver := q.version
+	ecl := q.errorCorrectionLevel
+	numBlocks := tableGet(&numErrorCorrectionBlocks, int(ver), ecl)
+	blockEccLen := tableGet(&eccCodewordsPerBlock, int(ver), ecl)
+	rawCodewords := len(allCodewords)
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	blockLens := make([]int, numBlocks)
+	for i := range blockLens {
+		blockLens[i] = shortBlockLen
+		if i >= numShortBlocks {
+			blockLens[i]++
+		}
+	}
+	blocks := make([][]byte, numBlocks)
+	for i, n := range blockLens {
+		blocks[i] = make([]byte, 0, n)
+	}
+
+	k := 0
+	for i := 0; i <= shortBlockLen; i++ {
+		for j := 0; j < numBlocks; j++ {
+			// Skip the padding byte in short blocks, matching addEccAndInterleave
+			if i != shortBlockLen-blockEccLen || j >= numShortBlocks {
+				blocks[j] = append(blocks[j], allCodewords[k])
+				k++
+			}
+		}
+	}
+
+	data := make([]byte, 0, getNumDataCodewords(ver, ecl))
+	for i, block := range blocks {
+		datLen := blockLens[i] - blockEccLen
+		data = append(data, block[:datLen]...)
+	}
+	return data
+}
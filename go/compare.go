@@ -0,0 +1,34 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import "github.com/nayuki/qrcodegen/qrsegment"
+
+// EncodeTextAllLevels returns a QR Code representing the given Unicode text
+// string at each of the four error correction levels, built from a single
+// shared segment construction.
+//
+// This is useful for callers that want to compare the resulting version or
+// symbol size across error correction levels before picking one, without
+// paying the cost of re-segmenting the text for every level. Each returned
+// QrCode is otherwise exactly as if it had been produced by EncodeText with
+// the corresponding ECC level argument.
+//
+// If the text does not fit any version at a given level, that level is
+// omitted from the result rather than failing the whole call; callers should
+// check len(result) or whether a specific level is present.
+func EncodeTextAllLevels(text string) map[QrCodeEcc]*QrCode {
+	segs := qrsegment.MakeSegments(text)
+	result := make(map[QrCodeEcc]*QrCode, 4)
+	for _, ecl := range []QrCodeEcc{Low, Medium, Quartile, High} {
+		if qr, err := EncodeSegments(segs, ecl); err == nil {
+			result[ecl] = qr
+		}
+	}
+	return result
+}
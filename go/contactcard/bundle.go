@@ -0,0 +1,44 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package contactcard
+
+import "github.com/nayuki/qrcodegen"
+
+// Bundle holds a pair of symbols encoding the same contact: VCard is the
+// full vCard record, scannable offline but often landing on a higher
+// version (and so a denser, larger-printed grid) because of the format's
+// verbosity; FallbackURL is a plain URL pointing at a hosted version of the
+// same contact, usually fitting a lower version at the cost of requiring
+// the scanner to be online.
+type Bundle struct {
+	VCard       *qrcodegen.QrCode
+	FallbackURL *qrcodegen.QrCode
+}
+
+// VersionSavings returns how many QR Code versions smaller FallbackURL is
+// than VCard (0 or negative if the URL symbol is not actually smaller,
+// e.g. a very long hosted-page URL).
+func (b Bundle) VersionSavings() int {
+	return int(b.VCard.Version()) - int(b.FallbackURL.Version())
+}
+
+// BuildBundle encodes card as a vCard symbol and fallbackURL as a plain URL
+// symbol, both at the same error correction level, so a caller can compare
+// their sizes (via Bundle.VersionSavings or by inspecting each symbol's
+// Version) and choose whichever fits a print layout's size constraint.
+func BuildBundle(card VCard, fallbackURL string, ecl qrcodegen.QrCodeEcc) (*Bundle, error) {
+	vcardQr, err := qrcodegen.EncodeText(card.Encode(), ecl)
+	if err != nil {
+		return nil, err
+	}
+	urlQr, err := qrcodegen.EncodeText(fallbackURL, ecl)
+	if err != nil {
+		return nil, err
+	}
+	return &Bundle{VCard: vcardQr, FallbackURL: urlQr}, nil
+}
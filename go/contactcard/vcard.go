@@ -0,0 +1,79 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Package contactcard builds the business-card payloads a scanner's
+// contacts app recognizes: vCard text, and a bundle helper that pairs it
+// with a smaller URL-based fallback symbol for print layouts where the
+// vCard's higher version (denser grid) won't fit. This package only builds
+// payload text and drives qrcodegen.EncodeText; it does not render images
+// itself.
+package contactcard
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VCard holds the fields Encode packs into a vCard 3.0 "BEGIN:VCARD" text
+// record, RFC 6350's predecessor format and the one most phone contacts
+// apps scan a QR Code's vCard payload as. Every field is optional; Encode
+// omits a property entirely when its field is empty.
+type VCard struct {
+	FirstName    string
+	LastName     string
+	Organization string
+	Title        string
+	Phone        string
+	Email        string
+	URL          string
+}
+
+// Encode returns v as a vCard 3.0 text record.
+func (v VCard) Encode() string {
+	var buf strings.Builder
+	buf.WriteString("BEGIN:VCARD\r\n")
+	buf.WriteString("VERSION:3.0\r\n")
+	if v.FirstName != "" || v.LastName != "" {
+		fmt.Fprintf(&buf, "N:%s;%s;;;\r\n", vcardEscape(v.LastName), vcardEscape(v.FirstName))
+		fmt.Fprintf(&buf, "FN:%s\r\n", vcardEscape(strings.TrimSpace(v.FirstName+" "+v.LastName)))
+	}
+	if v.Organization != "" {
+		fmt.Fprintf(&buf, "ORG:%s\r\n", vcardEscape(v.Organization))
+	}
+	if v.Title != "" {
+		fmt.Fprintf(&buf, "TITLE:%s\r\n", vcardEscape(v.Title))
+	}
+	if v.Phone != "" {
+		fmt.Fprintf(&buf, "TEL:%s\r\n", vcardEscape(v.Phone))
+	}
+	if v.Email != "" {
+		fmt.Fprintf(&buf, "EMAIL:%s\r\n", vcardEscape(v.Email))
+	}
+	if v.URL != "" {
+		fmt.Fprintf(&buf, "URL:%s\r\n", vcardEscape(v.URL))
+	}
+	buf.WriteString("END:VCARD\r\n")
+	return buf.String()
+}
+
+// vcardEscape escapes the characters vCard's text value grammar (RFC 6350
+// §3.4) reserves: backslash, comma, semicolon, and newline. RFC 6350 has no
+// literal CR or LF inside a value — only the \n escape represents a
+// newline — so every newline form (\r\n, bare \r, and bare \n) is folded
+// to \n before escaping; otherwise a field built from untrusted input
+// (e.g. a web form) containing a raw \r could splice what looks like an
+// extra content line into the record for a parser that splits lines on \r
+// as well as \n.
+func vcardEscape(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, ",", `\,`)
+	s = strings.ReplaceAll(s, ";", `\;`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return s
+}
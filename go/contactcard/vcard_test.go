@@ -0,0 +1,67 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package contactcard
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestVCardEscapeNormalizesNewlines checks that every newline form (a bare
+// \r, a bare \n, and \r\n) escapes to the same \n escape sequence, so a
+// field containing a raw \r can't be used to splice what looks like an
+// extra content line into the record for a parser that splits lines on \r
+// as well as \n.
+func TestVCardEscapeNormalizesNewlines(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"bare CR", "line one\rline two"},
+		{"bare LF", "line one\nline two"},
+		{"CRLF", "line one\r\nline two"},
+	}
+	const want = `line one\nline two`
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := vcardEscape(test.input); got != want {
+				t.Errorf("vcardEscape(%q) = %q, want %q", test.input, got, want)
+			}
+		})
+	}
+}
+
+// TestEncodeRejectsInjectedContentLine checks that a field containing an
+// embedded \r followed by what looks like another property (e.g.
+// "TEL:...") doesn't produce a raw CR anywhere in Encode's output: every
+// newline byte in the record must belong to one of the \r\n line endings
+// Encode itself writes between properties, not to attacker-controlled
+// field content.
+func TestEncodeRejectsInjectedContentLine(t *testing.T) {
+	v := VCard{
+		FirstName: "Eve",
+		LastName:  "Example",
+		Title:     "Manager\rTEL:+1-555-0100",
+	}
+	out := v.Encode()
+
+	// Every \r in the output must be immediately followed by \n, i.e. part
+	// of Encode's own line endings; an injected bare \r would violate
+	// this.
+	for i := 0; i < len(out); i++ {
+		if out[i] == '\r' && (i+1 >= len(out) || out[i+1] != '\n') {
+			t.Fatalf("Encode output contains a bare \\r not part of a \\r\\n line ending: %q", out)
+		}
+	}
+	if strings.Contains(out, "TITLE:Manager\r\n") {
+		t.Fatalf("embedded \\r in Title field was not escaped, splitting TITLE into two content lines: %q", out)
+	}
+	if !strings.Contains(out, `TITLE:Manager\nTEL:+1-555-0100`) {
+		t.Errorf("Encode output missing expected escaped TITLE line: %q", out)
+	}
+}
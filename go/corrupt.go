@@ -0,0 +1,126 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"fmt"
+
+	"github.com/nayuki/qrcodegen/mask"
+)
+
+// EncodeRawCodewords creates a new QR Code by drawing rawCodewords directly
+// onto the grid and masking with msk, without computing or validating any
+// error correction codewords. rawCodewords must already be in the same
+// interleaved order that AllCodewords returns, and its length must equal
+// getNumRawDataModules(ver)/8.
+//
+// This is a low-level API for building deliberately corrupted or otherwise
+// non-conforming symbols, such as for CorruptRawCodewords and CorruptBlocks,
+// or for researchers replaying modified codewords captured from AllCodewords.
+// Most callers that want a standard-conforming QR Code should use
+// EncodeCodewords instead.
+//
+// Panics if len(rawCodewords) is wrong.
+func EncodeRawCodewords(ver Version, ecl QrCodeEcc, msk Mask, rawCodewords []byte) *QrCode {
+	if len(rawCodewords) != getNumRawDataModules(ver)/8 {
+		panic("invalid number of raw codewords")
+	}
+	size := int32(ver)*4 + 17
+	result := &QrCode{
+		version:              ver,
+		size:                 size,
+		errorCorrectionLevel: ecl,
+		mask:                 mask.New(0), // Dummy value
+		modules:              make([]bool, size*size),
+		isFunction:           make([]bool, size*size),
+	}
+	result.drawFunctionPatterns()
+	result.drawCodewords(rawCodewords)
+	result.mask = msk
+	result.applyMask(msk)
+	result.drawFormatBits(msk)
+	return result
+}
+
+// CorruptRawCodewords returns a copy of qr with the raw codeword bytes at
+// the given indices (into AllCodewords's result) each flipped by XOR-ing
+// with 0xFF, for building scanner robustness test decks with a
+// reproducible, precisely located set of errors.
+//
+// Returns an error if any index is out of range.
+func CorruptRawCodewords(qr *QrCode, indices []int) (*QrCode, error) {
+	raw := qr.AllCodewords()
+	for _, i := range indices {
+		if i < 0 || i >= len(raw) {
+			return nil, fmt.Errorf("qrcodegen: codeword index %d out of range [0,%d)", i, len(raw))
+		}
+		raw[i] ^= 0xFF
+	}
+	return EncodeRawCodewords(qr.version, qr.errorCorrectionLevel, qr.mask, raw), nil
+}
+
+// CorruptBlocks returns a copy of qr with every codeword (both data and
+// error correction) in the given Reed-Solomon blocks (as indexed by
+// EccBlocks) flipped by XOR-ing with 0xFF, simulating the kind of burst
+// damage (a torn label, a smudge over one printed region) that ECC is
+// meant to recover from, or deliberately exceed.
+//
+// Returns an error if any block index is out of range.
+func CorruptBlocks(qr *QrCode, blockIndices []int) (*QrCode, error) {
+	blocks := qr.EccBlocks()
+	marked := make([]bool, len(blocks))
+	for _, i := range blockIndices {
+		if i < 0 || i >= len(blocks) {
+			return nil, fmt.Errorf("qrcodegen: block index %d out of range [0,%d)", i, len(blocks))
+		}
+		marked[i] = true
+	}
+	for i := range blocks {
+		if !marked[i] {
+			continue
+		}
+		for j := range blocks[i].Data {
+			blocks[i].Data[j] ^= 0xFF
+		}
+		for j := range blocks[i].Ecc {
+			blocks[i].Ecc[j] ^= 0xFF
+		}
+	}
+	raw := interleaveEccBlocks(blocks, qr.version, qr.errorCorrectionLevel)
+	return EncodeRawCodewords(qr.version, qr.errorCorrectionLevel, qr.mask, raw), nil
+}
+
+// interleaveEccBlocks is the inverse of the split performed by EccBlocks,
+// reassembling blocks back into the raw interleaved codeword order that
+// addEccAndInterleave produces.
+func interleaveEccBlocks(blocks []EccBlock, ver Version, ecl QrCodeEcc) []byte {
+	numBlocks := tableGet(&numErrorCorrectionBlocks, int(ver), ecl)
+	blockEccLen := tableGet(&eccCodewordsPerBlock, int(ver), ecl)
+	rawCodewords := getNumRawDataModules(ver) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	full := make([][]byte, numBlocks)
+	for i, b := range blocks {
+		dat := append([]byte{}, b.Data...)
+		if b.Short {
+			dat = append(dat, 0) // Padding byte that EccBlocks strips out; see addEccAndInterleave
+		}
+		full[i] = append(dat, b.Ecc...)
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := 0; i <= shortBlockLen; i++ {
+		for j, block := range full {
+			if i != shortBlockLen-blockEccLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"github.com/nayuki/qrcodegen/qrsegment"
+)
+
+// CostEstimate approximates the memory and CPU cost of encoding a payload,
+// derived purely from the version/ECC search, without building the actual
+// symbol. It lets a caller such as a request scheduler reject or downgrade
+// an oversized batch job before paying for the real encode.
+type CostEstimate struct {
+	// Version is the version EncodeSegmentsAdvanced would choose for this
+	// input.
+	Version Version
+	// ErrorCorrectionLevel is the level EncodeSegmentsAdvanced would use,
+	// which may be higher than the requested level if boostEcl is true.
+	ErrorCorrectionLevel QrCodeEcc
+	// GridModules is size*size, the number of modules in the symbol's
+	// grid, where size = int32(Version)*4 + 17.
+	GridModules int64
+	// GridBytes approximates the memory used by the symbol's two module
+	// grids (modules and isFunction), at one byte per bool as stored by
+	// the Go runtime.
+	GridBytes int64
+	// MaskEvaluations is the number of masks that get drawn and scored
+	// against the penalty rules: 8 when no mask is forced, 1 when the
+	// caller forces a single mask.
+	MaskEvaluations int
+	// EstimatedMaskOperations approximates the CPU cost of mask
+	// application and penalty scoring, as GridModules * MaskEvaluations.
+	EstimatedMaskOperations int64
+}
+
+// EstimateEncodingCost approximates the memory and CPU cost of encoding segs
+// at some version in [minVersion, maxVersion], without actually encoding
+// it: it runs the same version-search and ECC-boost logic as
+// EncodeSegmentsAdvanced, then derives the grid size and mask-evaluation
+// cost from the chosen version, skipping codeword assembly, drawing, and
+// masking entirely.
+//
+// forceMask should report whether the corresponding EncodeSegmentsAdvanced
+// call would pass a non-nil msk, which skips evaluating the other 7 masks.
+//
+// The returned error is the same *ErrDataTooLong or *ErrVersionRange that
+// EncodeSegmentsAdvanced would return for this input.
+func EstimateEncodingCost(segs []*qrsegment.QrSegment, ecl QrCodeEcc, minVersion, maxVersion Version, boostEcl, forceMask bool) (*CostEstimate, error) {
+	if minVersion > maxVersion {
+		return nil, &ErrVersionRange{MinVersion: minVersion, MaxVersion: maxVersion}
+	}
+
+	// Find the minimal version number to use
+	version := minVersion
+	var dataUsedBits int
+	for {
+		dataCapacityBits := getNumDataCodewords(version, ecl) * 8
+		dataUsed, ok := qrsegment.GetTotalBits(segs, version)
+		if ok && dataUsed <= dataCapacityBits {
+			dataUsedBits = dataUsed
+			break
+		}
+		if version >= maxVersion {
+			return nil, newErrDataTooLong(segs, ok, dataUsed, dataCapacityBits, version, ecl)
+		}
+		version++
+	}
+
+	// Increase the error correction level while the data still fits in the current version number
+	for _, newEcl := range []QrCodeEcc{Medium, Quartile, High} { // From low to high
+		if boostEcl && dataUsedBits <= getNumDataCodewords(version, newEcl)*8 {
+			ecl = newEcl
+		}
+	}
+
+	size := int64(int32(version)*4 + 17)
+	gridModules := size * size
+	maskEvaluations := 8
+	if forceMask {
+		maskEvaluations = 1
+	}
+	return &CostEstimate{
+		Version:                 version,
+		ErrorCorrectionLevel:    ecl,
+		GridModules:             gridModules,
+		GridBytes:               gridModules * 2, // modules and isFunction grids
+		MaskEvaluations:         maskEvaluations,
+		EstimatedMaskOperations: gridModules * int64(maskEvaluations),
+	}, nil
+}
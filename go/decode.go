@@ -0,0 +1,179 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"fmt"
+
+	"github.com/nayuki/qrcodegen/bch"
+	"github.com/nayuki/qrcodegen/mask"
+	"github.com/nayuki/qrcodegen/qrsegment"
+	"github.com/nayuki/qrcodegen/rs"
+)
+
+// DecodeModules reconstructs a QrCode from a scanned module grid, grid[y][x]
+// with true meaning dark, not including the quiet zone border. It recovers
+// the version from the grid's size, and the error correction level and mask
+// pattern from the format bits (trying both copies, and correcting up to 3
+// bit errors in whichever is used, the same as a physical scanner would).
+//
+// This only rebuilds the symbol's structure; it does not validate or
+// correct the payload's Reed-Solomon codewords. Use DecodeText, or
+// CorrectedDataCodewords for lower-level access, for that.
+//
+// Returns an error if the grid size doesn't correspond to a valid QR Code
+// version, the rows have inconsistent widths, the format bits can't be
+// recovered from either copy, or (for version 7 and up) the two copies of
+// the version bits disagree with the grid size after error correction.
+func DecodeModules(grid [][]bool) (*QrCode, error) {
+	size := int32(len(grid))
+	if (size-17)%4 != 0 {
+		return nil, fmt.Errorf("qrcodegen: grid size %d does not correspond to a QR Code version", size)
+	}
+	ver := Version((size - 17) / 4)
+	if ver < MinVersion || ver > MaxVersion {
+		return nil, fmt.Errorf("qrcodegen: grid size %d is outside the supported version range", size)
+	}
+	for _, row := range grid {
+		if int32(len(row)) != size {
+			return nil, fmt.Errorf("qrcodegen: grid rows have inconsistent widths")
+		}
+	}
+
+	q := &QrCode{
+		version:    ver,
+		size:       size,
+		mask:       mask.New(0), // Placeholder, overwritten below once the format bits are known
+		modules:    make([]bool, size*size),
+		isFunction: make([]bool, size*size),
+	}
+	q.drawFunctionPatterns()
+	for y := int32(0); y < size; y++ {
+		for x := int32(0); x < size; x++ {
+			q.modules[y*size+x] = grid[y][x]
+		}
+	}
+
+	formatData, ok := bch.FormatDecode(q.readFormatBits(true))
+	if !ok {
+		formatData, ok = bch.FormatDecode(q.readFormatBits(false))
+	}
+	if !ok {
+		return nil, fmt.Errorf("qrcodegen: could not recover format information from either copy")
+	}
+	ecl, ok := eccFromFormatBits(formatData >> 3)
+	if !ok {
+		return nil, fmt.Errorf("qrcodegen: decoded format information names an invalid error correction level")
+	}
+	q.errorCorrectionLevel = ecl
+	q.mask = mask.New(int32(formatData & 7))
+
+	if ver >= 7 {
+		versionData, ok := bch.VersionDecode(q.readVersionBits(true))
+		if !ok {
+			versionData, ok = bch.VersionDecode(q.readVersionBits(false))
+		}
+		if ok && Version(versionData) != ver {
+			return nil, fmt.Errorf("qrcodegen: version bits (%d) disagree with the version implied by the grid size (%d)", versionData, ver)
+		}
+	}
+
+	return q, nil
+}
+
+// readFormatBits reads the observed 15-bit value of the first (top-left) or
+// second (split across the bottom-left and top-right) copy of the format
+// bits drawFormatBits wrote, for BCH error correction.
+func (q *QrCode) readFormatBits(firstCopy bool) uint32 {
+	var bits uint32
+	if firstCopy {
+		for i := int32(0); i < 6; i++ {
+			bits |= b2u32(q.module(8, i)) << uint(i)
+		}
+		bits |= b2u32(q.module(8, 7)) << 6
+		bits |= b2u32(q.module(8, 8)) << 7
+		bits |= b2u32(q.module(7, 8)) << 8
+		for i := int32(9); i < 15; i++ {
+			bits |= b2u32(q.module(14-i, 8)) << uint(i)
+		}
+	} else {
+		size := q.size
+		for i := int32(0); i < 8; i++ {
+			bits |= b2u32(q.module(size-1-i, 8)) << uint(i)
+		}
+		for i := int32(8); i < 15; i++ {
+			bits |= b2u32(q.module(8, size-15+i)) << uint(i)
+		}
+	}
+	return bits
+}
+
+// readVersionBits reads the observed 18-bit value of one of the two
+// identical copies of the version bits drawVersion wrote (true selects the
+// copy in the bottom-left corner, false the top-right), for BCH error
+// correction. Only meaningful for version 7 and up.
+func (q *QrCode) readVersionBits(bottomLeft bool) uint32 {
+	var bits uint32
+	for i := int32(0); i < 18; i++ {
+		a := q.size - 11 + i%3
+		b := i / 3
+		var bit bool
+		if bottomLeft {
+			bit = q.module(b, a)
+		} else {
+			bit = q.module(a, b)
+		}
+		bits |= b2u32(bit) << uint(i)
+	}
+	return bits
+}
+
+// eccFromFormatBits reverses QrCodeEcc.formatBits.
+func eccFromFormatBits(bits uint32) (QrCodeEcc, bool) {
+	for _, ecl := range []QrCodeEcc{Low, Medium, Quartile, High} {
+		if ecl.formatBits() == bits {
+			return ecl, true
+		}
+	}
+	return 0, false
+}
+
+// CorrectedDataCodewords is like DataCodewords, but first runs each
+// Reed-Solomon block through rs.Correct, fixing up to half of that block's
+// error correction codewords' worth of byte errors.
+//
+// Returns an error if any block has more errors than its error correction
+// level can guarantee to fix.
+func (q *QrCode) CorrectedDataCodewords() ([]byte, error) {
+	blocks := q.EccBlocks()
+	result := make([]byte, 0, getNumDataCodewords(q.version, q.errorCorrectionLevel))
+	for _, b := range blocks {
+		codeword := append(append([]byte{}, b.Data...), b.Ecc...)
+		corrected, err := rs.Correct(codeword, len(b.Ecc))
+		if err != nil {
+			return nil, fmt.Errorf("qrcodegen: block %d: %w", b.Index, err)
+		}
+		result = append(result, corrected[:len(b.Data)]...)
+	}
+	return result, nil
+}
+
+// DecodeText recovers the Unicode text that q's payload encodes, reversing
+// EncodeText and EncodeSegments for Numeric, Alphanumeric, Byte, Kanji, and
+// Eci segments.
+func (q *QrCode) DecodeText() (string, error) {
+	data, err := q.CorrectedDataCodewords()
+	if err != nil {
+		return "", err
+	}
+	segs, err := qrsegment.DecodeSegments(data, q.version)
+	if err != nil {
+		return "", err
+	}
+	return qrsegment.SegmentsToText(segs)
+}
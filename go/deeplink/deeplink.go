@@ -0,0 +1,52 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Package deeplink builds the URLs a handful of messaging apps recognize as
+// deep links: opening them jumps straight to a chat with a prefilled
+// message, rather than just to the app itself. Getting the number
+// normalization and percent-encoding right by hand is a frequent source of
+// broken marketing QR Codes; this package only builds the URL text, leaving
+// encoding it into a symbol to the caller, e.g.
+// qrcodegen.EncodeText(url, qrcodegen.Medium).
+package deeplink
+
+import (
+	"fmt"
+	"strings"
+)
+
+// normalizePhoneNumber strips the formatting (spaces, dashes, parentheses,
+// and a leading "+") that phone numbers are conventionally displayed with,
+// returning the bare digit string the wa.me link format requires: a full
+// international number including country code, with no leading zeros or
+// punctuation.
+//
+// Returns an error if the result isn't all digits, starts with a zero, or
+// isn't a plausible E.164 length (8 to 15 digits).
+func normalizePhoneNumber(number string) (string, error) {
+	var digits strings.Builder
+	for _, r := range number {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && digits.Len() == 0:
+			// A leading "+" is conventional punctuation, not a digit.
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.':
+			// Ignored formatting.
+		default:
+			return "", fmt.Errorf("deeplink: %q contains non-phone-number character %q", number, r)
+		}
+	}
+	s := digits.String()
+	if len(s) < 8 || len(s) > 15 {
+		return "", fmt.Errorf("deeplink: %q normalizes to %d digits, outside the 8-15 digit E.164 range", number, len(s))
+	}
+	if s[0] == '0' {
+		return "", fmt.Errorf("deeplink: %q starts with a zero, not an international number with country code", number)
+	}
+	return s, nil
+}
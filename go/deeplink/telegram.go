@@ -0,0 +1,67 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package deeplink
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// TelegramUser returns a https://t.me/ link that opens a chat with the
+// given username, stripping a leading "@" if present.
+//
+// Returns an error if username (with any leading "@" removed) is not 5-32
+// characters of letters, digits, and underscores starting with a letter,
+// Telegram's own username format.
+func TelegramUser(username string) (string, error) {
+	if len(username) > 0 && username[0] == '@' {
+		username = username[1:]
+	}
+	if err := validateTelegramUsername(username); err != nil {
+		return "", err
+	}
+	return "https://t.me/" + username, nil
+}
+
+// TelegramPhone returns a https://t.me/ link that opens a chat with the
+// contact at number, for contacts with no public username. number is
+// normalized the same way WhatsApp's is; see normalizePhoneNumber.
+func TelegramPhone(number string) (string, error) {
+	digits, err := normalizePhoneNumber(number)
+	if err != nil {
+		return "", err
+	}
+	return "https://t.me/+" + digits, nil
+}
+
+// TelegramShare returns a https://t.me/share link that opens Telegram's
+// share dialog prefilled with pageURL and, if non-empty, text.
+func TelegramShare(pageURL, text string) string {
+	v := url.Values{"url": {pageURL}}
+	if text != "" {
+		v.Set("text", text)
+	}
+	return "https://t.me/share/url?" + v.Encode()
+}
+
+func validateTelegramUsername(username string) error {
+	if len(username) < 5 || len(username) > 32 {
+		return fmt.Errorf("deeplink: username %q must be 5-32 characters", username)
+	}
+	for i, r := range username {
+		isLetter := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+		isDigit := r >= '0' && r <= '9'
+		if i == 0 && !isLetter {
+			return fmt.Errorf("deeplink: username %q must start with a letter", username)
+		}
+		if !isLetter && !isDigit && r != '_' {
+			return fmt.Errorf("deeplink: username %q contains invalid character %q", username, r)
+		}
+	}
+	return nil
+}
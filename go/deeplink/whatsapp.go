@@ -0,0 +1,33 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package deeplink
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// WhatsApp returns a https://wa.me/ link that opens a chat with number,
+// prefilled with text if non-empty.
+//
+// number may be written with the conventional punctuation (spaces, dashes,
+// parentheses, a leading "+"); it is normalized to wa.me's required bare
+// digit string, a full international number including country code with no
+// leading zeros. Returns an error if number does not normalize to a
+// plausible phone number.
+func WhatsApp(number, text string) (string, error) {
+	digits, err := normalizePhoneNumber(number)
+	if err != nil {
+		return "", err
+	}
+	link := fmt.Sprintf("https://wa.me/%s", digits)
+	if text != "" {
+		link += "?text=" + url.QueryEscape(text)
+	}
+	return link, nil
+}
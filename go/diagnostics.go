@@ -0,0 +1,184 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nayuki/qrcodegen/mask"
+	"github.com/nayuki/qrcodegen/qrsegment"
+)
+
+// SegmentDiagnostic reports the mode, character count, and bit cost of one
+// segment as it was actually encoded into a symbol.
+type SegmentDiagnostic struct {
+	Mode      qrsegment.QrSegmentMode
+	NumChars  int
+	BitLength int
+}
+
+// StageTiming reports how long one pipeline stage took while producing a
+// Diagnostics result. Stage is one of "version_search", "ecc_boost",
+// "assemble_codewords", or "mask_selection", matching the stage names
+// EncodeSegmentsAdvancedContext reports to an Observer.
+type StageTiming struct {
+	Stage    string
+	Duration time.Duration
+}
+
+// Diagnostics reports why EncodeSegmentsAdvancedWithDiagnostics made the
+// choices it did, for callers debugging unexpectedly large or oddly masked
+// symbols.
+type Diagnostics struct {
+	// Version is the chosen QR Code version.
+	Version Version
+	// VersionReason explains why Version was chosen.
+	VersionReason string
+	// Segments describes each input segment as encoded at Version.
+	Segments []SegmentDiagnostic
+	// RequestedErrorCorrectionLevel is the ecl argument as passed in.
+	RequestedErrorCorrectionLevel QrCodeEcc
+	// ErrorCorrectionLevel is the level actually used, which may be higher
+	// than RequestedErrorCorrectionLevel if EccBoosted is true.
+	ErrorCorrectionLevel QrCodeEcc
+	// EccBoosted reports whether the error correction level was raised
+	// above the requested level because doing so didn't need a larger
+	// version.
+	EccBoosted bool
+	// MaskPenalties holds the penalty score of every one of the 8 standard
+	// masks, indexed by mask value, regardless of whether a mask was
+	// forced by the caller.
+	MaskPenalties [8]int32
+	// ChosenMask is the mask that was actually applied to the symbol.
+	ChosenMask Mask
+	// PaddingBitsUsed is the number of terminator and padding bits
+	// appended after the concatenated segment data.
+	PaddingBitsUsed int
+	// StageTimings holds how long each pipeline stage took, in the order
+	// the stages ran. Each stage also runs under a pprof label, so this
+	// is redundant with a CPU profile collected at the same time; it
+	// exists for callers who want stage latency without attaching a
+	// profiler.
+	StageTimings []StageTiming
+}
+
+// EncodeSegmentsAdvancedWithDiagnostics behaves exactly like
+// EncodeSegmentsAdvanced, but additionally returns a Diagnostics value
+// describing the choices made while encoding: the chosen version and why,
+// each segment's mode and bit cost, whether the error correction level was
+// boosted, the penalty score of all 8 masks, the amount of padding used, and
+// each pipeline stage's wall-clock duration. As with
+// EncodeSegmentsAdvancedContext, every stage also runs under a pprof label
+// "qrcodegen_stage", so a CPU profile taken at the same time attributes the
+// same breakdown.
+//
+// This is invaluable for answering questions like "why is my code version
+// 12?" without having to reimplement the search logic externally.
+func EncodeSegmentsAdvancedWithDiagnostics(segs []*qrsegment.QrSegment, ecl QrCodeEcc, minVersion, maxVersion Version, msk *Mask, boostEcl bool) (*QrCode, *Diagnostics, error) {
+	if minVersion > maxVersion {
+		return nil, nil, &ErrVersionRange{MinVersion: minVersion, MaxVersion: maxVersion}
+	}
+	if msk != nil {
+		if _, err := mask.NewChecked(msk.Value()); err != nil {
+			return nil, nil, fmt.Errorf("qrcodegen: invalid mask: %w", err)
+		}
+	}
+	requestedEcl := ecl
+	ctx := context.Background()
+	var timings []StageTiming
+	observer := stageTimingObserver{&timings}
+
+	// Find the minimal version number to use
+	version := minVersion
+	var dataUsedBits int
+	var versionReason string
+	err := observeStage(ctx, observer, "version_search", func() error {
+		for {
+			dataCapacityBits := getNumDataCodewords(version, ecl) * 8
+			dataUsed, ok := qrsegment.GetTotalBits(segs, version)
+			if ok && dataUsed <= dataCapacityBits {
+				dataUsedBits = dataUsed
+				if version == minVersion {
+					versionReason = fmt.Sprintf("smallest allowed version (%d) already fits the data (%d of %d bits used)", version, dataUsed, dataCapacityBits)
+				} else {
+					versionReason = fmt.Sprintf("smallest version in [%d, %d] that fits the data (%d of %d bits used)", minVersion, maxVersion, dataUsed, dataCapacityBits)
+				}
+				return nil
+			}
+			if version >= maxVersion {
+				return newErrDataTooLong(segs, ok, dataUsed, dataCapacityBits, version, ecl)
+			}
+			version++
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Increase the error correction level while the data still fits in the current version number
+	eccBoosted := false
+	observeStage(ctx, observer, "ecc_boost", func() error {
+		for _, newEcl := range []QrCodeEcc{Medium, Quartile, High} { // From low to high
+			if boostEcl && dataUsedBits <= getNumDataCodewords(version, newEcl)*8 {
+				ecl = newEcl
+				eccBoosted = ecl != requestedEcl
+			}
+		}
+		return nil
+	})
+
+	segDiags := make([]SegmentDiagnostic, len(segs))
+	for i, seg := range segs {
+		n, _ := seg.TotalBitsAt(version)
+		segDiags[i] = SegmentDiagnostic{Mode: seg.Mode(), NumChars: seg.NumChars(), BitLength: n}
+	}
+
+	var dataCodewords []byte
+	var paddingBits int
+	observeStage(ctx, observer, "assemble_codewords", func() error {
+		dataCodewords, paddingBits = assembleDataCodewords(segs, version, ecl)
+		return nil
+	})
+
+	var qr *QrCode
+	var chosenMask Mask
+	var penalties [8]int32
+	observeStage(ctx, observer, "mask_selection", func() error {
+		qr, chosenMask = encodeCodewords(version, ecl, dataCodewords, msk, AllMasks, &penalties)
+		return nil
+	})
+
+	diag := &Diagnostics{
+		Version:                       version,
+		VersionReason:                 versionReason,
+		Segments:                      segDiags,
+		RequestedErrorCorrectionLevel: requestedEcl,
+		ErrorCorrectionLevel:          ecl,
+		EccBoosted:                    eccBoosted,
+		MaskPenalties:                 penalties,
+		ChosenMask:                    chosenMask,
+		PaddingBitsUsed:               paddingBits,
+		StageTimings:                  timings,
+	}
+	return qr, diag, nil
+}
+
+// stageTimingObserver is an Observer that appends each stage's duration to
+// timings, for EncodeSegmentsAdvancedWithDiagnostics's StageTimings result.
+type stageTimingObserver struct {
+	timings *[]StageTiming
+}
+
+func (o stageTimingObserver) StageStart(_ context.Context, stage string) func(error) {
+	start := time.Now()
+	return func(error) {
+		*o.timings = append(*o.timings, StageTiming{Stage: stage, Duration: time.Since(start)})
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+// QrCodeEcc is the error correction level in a QR Code symbol.
+type QrCodeEcc int
+
+const (
+	// Low tolerates about 7% erroneous codewords.
+	Low QrCodeEcc = iota
+	// Medium tolerates about 15% erroneous codewords.
+	Medium
+	// Quartile tolerates about 25% erroneous codewords.
+	Quartile
+	// High tolerates about 30% erroneous codewords.
+	High
+)
+
+// ordinal returns an unsigned 2-bit integer (in the range 0 to 3).
+func (e QrCodeEcc) ordinal() int {
+	return int(e)
+}
+
+// formatBits returns an unsigned 2-bit integer (in the range 0 to 3) used in
+// the format bits of a QR Code symbol.
+func (e QrCodeEcc) formatBits() uint32 {
+	switch e {
+	case Low:
+		return 1
+	case Medium:
+		return 0
+	case Quartile:
+		return 3
+	case High:
+		return 2
+	default:
+		panic("invalid error correction level")
+	}
+}
+
+func (e QrCodeEcc) String() string {
+	switch e {
+	case Low:
+		return "Low"
+	case Medium:
+		return "Medium"
+	case Quartile:
+		return "Quartile"
+	case High:
+		return "High"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+// EccBlock is one Reed-Solomon block of this symbol's codewords, as split
+// out by EccBlocks.
+type EccBlock struct {
+	// Index is this block's position (0-based) in the interleaving order
+	// used by addEccAndInterleave, matching the block numbering in
+	// ISO/IEC 18004 table 9.
+	Index int
+
+	// Data holds this block's data codewords, i.e. the original payload
+	// bytes before error correction was computed.
+	Data []byte
+
+	// Ecc holds this block's error correction codewords, computed over
+	// Data by Reed-Solomon encoding.
+	Ecc []byte
+
+	// Short is true if this block has one fewer data codeword than the
+	// long blocks of this symbol's version and error correction level
+	// (ISO/IEC 18004 allows a version/ECL combination to mix block
+	// lengths; the short blocks always come first in interleaving order).
+	Short bool
+}
+
+// EccBlocks splits this symbol's codewords (as recovered by AllCodewords)
+// back into the per-block data/ECC layout that addEccAndInterleave
+// produced when constructing it, for verifying a decoder or firmware
+// implementation against the spec's worked examples.
+func (q *QrCode) EccBlocks() []EccBlock {
+	allCodewords := q.AllCodewords()
+
+	ver := q.version
+	ecl := q.errorCorrectionLevel
+	numBlocks := tableGet(&numErrorCorrectionBlocks, int(ver), ecl)
+	blockEccLen := tableGet(&eccCodewordsPerBlock, int(ver), ecl)
+	rawCodewords := len(allCodewords)
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	blocks := make([]EccBlock, numBlocks)
+	for i := range blocks {
+		datLen := shortBlockLen - blockEccLen
+		short := i < numShortBlocks
+		if !short {
+			datLen++
+		}
+		blocks[i] = EccBlock{
+			Index: i,
+			Data:  make([]byte, 0, datLen),
+			Ecc:   make([]byte, 0, blockEccLen),
+			Short: short,
+		}
+	}
+
+	k := 0
+	for i := 0; i <= shortBlockLen; i++ {
+		for j := range blocks {
+			// Skip the padding byte in short blocks, matching addEccAndInterleave
+			if i == shortBlockLen-blockEccLen && j < numShortBlocks {
+				continue
+			}
+			b := &blocks[j]
+			if len(b.Data) < cap(b.Data) {
+				b.Data = append(b.Data, allCodewords[k])
+			} else {
+				b.Ecc = append(b.Ecc, allCodewords[k])
+			}
+			k++
+		}
+	}
+	return blocks
+}
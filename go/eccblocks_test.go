@@ -0,0 +1,80 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"bytes"
+	"testing"
+	"testing/quick"
+
+	"github.com/nayuki/qrcodegen/rs"
+)
+
+// TestEccBlocksMatchesDataAndEcc checks that EccBlocks' per-block split is
+// consistent with the symbol's actual codewords across versions and error
+// correction levels: the blocks' Data concatenates back to DataCodewords,
+// each block's Ecc is exactly what Reed-Solomon encoding of its Data
+// produces, Short correctly separates the short blocks (which interleaving
+// always places first) from the long ones, and the block count and lengths
+// match the spec tables addEccAndInterleave consults.
+func TestEccBlocksMatchesDataAndEcc(t *testing.T) {
+	f := func(verOffset uint8, eclOffset uint8, seed uint32) bool {
+		ver := MinVersion + Version(verOffset)%(MaxVersion-MinVersion+1)
+		ecl := QrCodeEcc(int(eclOffset) % 4)
+
+		data := make([]byte, getNumDataCodewords(ver, ecl))
+		state := seed | 1
+		for i := range data {
+			state = state*1664525 + 1013904223
+			data[i] = byte(state >> 24)
+		}
+
+		qr := EncodeCodewords(ver, ecl, data, nil)
+		blocks := qr.EccBlocks()
+
+		numBlocks := tableGet(&numErrorCorrectionBlocks, int(ver), ecl)
+		blockEccLen := tableGet(&eccCodewordsPerBlock, int(ver), ecl)
+		rawCodewords := getNumRawDataModules(ver) / 8
+		numShortBlocks := numBlocks - rawCodewords%numBlocks
+		shortBlockLen := rawCodewords / numBlocks
+
+		if len(blocks) != numBlocks {
+			return false
+		}
+
+		var reassembledData []byte
+		for i, b := range blocks {
+			if b.Index != i {
+				return false
+			}
+			wantShort := i < numShortBlocks
+			if b.Short != wantShort {
+				return false
+			}
+			wantDatLen := shortBlockLen - blockEccLen
+			if !wantShort {
+				wantDatLen++
+			}
+			if len(b.Data) != wantDatLen {
+				return false
+			}
+			if len(b.Ecc) != blockEccLen {
+				return false
+			}
+			wantEcc := rs.ComputeRemainder(b.Data, rs.ComputeDivisor(blockEccLen))
+			if !bytes.Equal(b.Ecc, wantEcc) {
+				return false
+			}
+			reassembledData = append(reassembledData, b.Data...)
+		}
+		return bytes.Equal(reassembledData, data) && bytes.Equal(reassembledData, qr.DataCodewords())
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 100}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Package emvqr builds the TLV-encoded text that the EMVCo QR Code
+// Specification for Payment Systems (Merchant Presented Mode) requires: a
+// flat sequence of two-digit-tag, two-digit-length, value fields, some of
+// which nest another such sequence as their value, terminated by a
+// CRC-16/CCITT-FALSE checksum over everything before it.
+//
+// PromptPay (Thailand) and QRIS (Indonesia) are the two national profiles
+// built on top of this TLV scheme that this package provides helpers for;
+// Build assembles any profile's payload once its merchant account
+// information template is known. This package only builds the payload
+// text, leaving encoding it into a symbol to the caller, e.g.
+// qrcodegen.EncodeText(payload, qrcodegen.Medium).
+package emvqr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Field is one TLV entry: a two-digit ID, either holding Value directly or,
+// if Children is non-empty, holding the serialization of Children as a
+// nested TLV sequence (an EMVCo "template"). Value and Children are
+// mutually exclusive.
+type Field struct {
+	ID       string
+	Value    string
+	Children []Field
+}
+
+// serialize returns f's ID, its two-digit length, and its value,
+// concatenated.
+//
+// Returns an error if ID is not exactly two digits, or the value (direct or
+// the serialized Children) is longer than 99 bytes, the most a two-digit
+// length can express.
+func (f Field) serialize() (string, error) {
+	if len(f.ID) != 2 || !isAllDigits(f.ID) {
+		return "", fmt.Errorf("emvqr: field ID %q must be exactly 2 digits", f.ID)
+	}
+	value := f.Value
+	if len(f.Children) > 0 {
+		var buf strings.Builder
+		for _, child := range f.Children {
+			s, err := child.serialize()
+			if err != nil {
+				return "", err
+			}
+			buf.WriteString(s)
+		}
+		value = buf.String()
+	}
+	if len(value) > 99 {
+		return "", fmt.Errorf("emvqr: field %s value is %d bytes, exceeding the 99-byte limit a 2-digit length can express", f.ID, len(value))
+	}
+	return fmt.Sprintf("%s%02d%s", f.ID, len(value), value), nil
+}
+
+// Payload holds the fields common to every EMVCo MPM profile. MerchantAccountInformation
+// carries the single network-specific template (PromptPay, QRIS, or
+// another network's), since EMVCo reserves IDs 02-51 for that purpose and a
+// payload names exactly the one network it targets.
+type Payload struct {
+	// PointOfInitiationMethod is "11" for a static, reusable code or "12"
+	// for a dynamic, single-transaction code. Empty defaults to "11".
+	PointOfInitiationMethod string
+
+	// MerchantAccountInformation is the network-specific template, with an
+	// ID EMVCo assigns that network (e.g. "29" for PromptPay).
+	MerchantAccountInformation Field
+
+	// MerchantCategoryCode is the 4-digit ISO 18245 category code.
+	MerchantCategoryCode string
+
+	// TransactionCurrency is the 3-digit ISO 4217 numeric currency code.
+	TransactionCurrency string
+
+	// TransactionAmount is the decimal transaction amount, formatted per
+	// EMVCo's rules (a period decimal point, no thousands separator, no
+	// currency symbol). Empty omits the field, making the code reusable
+	// for any amount.
+	TransactionAmount string
+
+	// CountryCode is the 2-letter ISO 3166-1 alpha-2 country code.
+	CountryCode string
+
+	// MerchantName and MerchantCity identify the payee.
+	MerchantName string
+	MerchantCity string
+
+	// PostalCode is optional.
+	PostalCode string
+
+	// AdditionalData, if non-empty, becomes the children of the
+	// Additional Data Field Template (tag 62), e.g. a bill number (01) or
+	// reference label (05).
+	AdditionalData []Field
+}
+
+// Build assembles p into a complete EMVCo MPM payload string, appending the
+// mandatory CRC-16/CCITT-FALSE checksum (tag 63) over every field that
+// precedes it.
+//
+// Returns an error if MerchantCategoryCode is not 4 digits, TransactionCurrency
+// is not 3 digits, CountryCode is not 2 letters, or any field fails to
+// serialize (see Field.serialize).
+func (p Payload) Build() (string, error) {
+	if len(p.MerchantCategoryCode) != 4 || !isAllDigits(p.MerchantCategoryCode) {
+		return "", fmt.Errorf("emvqr: merchant category code %q must be exactly 4 digits", p.MerchantCategoryCode)
+	}
+	if len(p.TransactionCurrency) != 3 || !isAllDigits(p.TransactionCurrency) {
+		return "", fmt.Errorf("emvqr: transaction currency %q must be exactly 3 digits", p.TransactionCurrency)
+	}
+	isLetter := func(c byte) bool { return c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' }
+	if len(p.CountryCode) != 2 || !isLetter(p.CountryCode[0]) || !isLetter(p.CountryCode[1]) {
+		return "", fmt.Errorf("emvqr: country code %q must be exactly 2 letters", p.CountryCode)
+	}
+
+	poi := p.PointOfInitiationMethod
+	if poi == "" {
+		poi = "11"
+	}
+
+	fields := []Field{
+		{ID: "00", Value: "01"},
+		{ID: "01", Value: poi},
+		p.MerchantAccountInformation,
+		{ID: "52", Value: p.MerchantCategoryCode},
+		{ID: "53", Value: p.TransactionCurrency},
+	}
+	if p.TransactionAmount != "" {
+		fields = append(fields, Field{ID: "54", Value: p.TransactionAmount})
+	}
+	fields = append(fields,
+		Field{ID: "58", Value: p.CountryCode},
+		Field{ID: "59", Value: p.MerchantName},
+		Field{ID: "60", Value: p.MerchantCity},
+	)
+	if p.PostalCode != "" {
+		fields = append(fields, Field{ID: "61", Value: p.PostalCode})
+	}
+	if len(p.AdditionalData) > 0 {
+		fields = append(fields, Field{ID: "62", Children: p.AdditionalData})
+	}
+
+	var buf strings.Builder
+	for _, f := range fields {
+		s, err := f.serialize()
+		if err != nil {
+			return "", err
+		}
+		buf.WriteString(s)
+	}
+	buf.WriteString("6304")
+
+	withoutChecksum := buf.String()
+	return fmt.Sprintf("%s%04X", withoutChecksum, crc16CCITTFalse([]byte(withoutChecksum))), nil
+}
+
+// crc16CCITTFalse computes the CRC-16/CCITT-FALSE checksum EMVCo's tag 63
+// requires: polynomial 0x1021, initial value 0xFFFF, MSB first, no final
+// XOR.
+func crc16CCITTFalse(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = (crc << 1) ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
@@ -0,0 +1,148 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package emvqr
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCRC16CCITTFalseCheckValue checks crc16CCITTFalse against the
+// standard CRC-16/CCITT-FALSE check value (the CRC of the ASCII string
+// "123456789"), the usual cross-check for this exact poly/init/xorout
+// combination.
+func TestCRC16CCITTFalseCheckValue(t *testing.T) {
+	const want = 0x29B1
+	if got := crc16CCITTFalse([]byte("123456789")); got != want {
+		t.Errorf("crc16CCITTFalse(\"123456789\") = %#04X, want %#04X", got, want)
+	}
+}
+
+// TestFieldSerialize checks that serialize prepends the two-digit ID and
+// two-digit length to a plain value, and recursively serializes Children
+// into the value of a nested template.
+func TestFieldSerialize(t *testing.T) {
+	f := Field{ID: "59", Value: "Jane Doe"}
+	got, err := f.serialize()
+	if err != nil {
+		t.Fatalf("serialize() returned an error: %v", err)
+	}
+	if want := "5908Jane Doe"; got != want {
+		t.Errorf("serialize() = %q, want %q", got, want)
+	}
+
+	nested := Field{ID: "62", Children: []Field{
+		{ID: "01", Value: "123456"},
+		{ID: "05", Value: "ref"},
+	}}
+	got, err = nested.serialize()
+	if err != nil {
+		t.Fatalf("serialize() returned an error: %v", err)
+	}
+	if want := "62" + "17" + "0106123456" + "0503ref"; got != want {
+		t.Errorf("serialize() = %q, want %q", got, want)
+	}
+}
+
+// TestFieldSerializeRejectsBadID checks that serialize rejects an ID that
+// isn't exactly two characters, instead of writing a malformed TLV entry
+// that would desynchronize every field after it.
+func TestFieldSerializeRejectsBadID(t *testing.T) {
+	if _, err := (Field{ID: "5", Value: "x"}).serialize(); err == nil {
+		t.Error("serialize() with a 1-character ID returned no error")
+	}
+	if _, err := (Field{ID: "", Value: "x"}).serialize(); err == nil {
+		t.Error("serialize() with an empty ID returned no error")
+	}
+}
+
+// TestFieldSerializeRejectsOverlongValue checks that serialize rejects a
+// value longer than 99 bytes, the most a two-digit length can express.
+func TestFieldSerializeRejectsOverlongValue(t *testing.T) {
+	f := Field{ID: "62", Value: strings.Repeat("x", 100)}
+	if _, err := f.serialize(); err == nil {
+		t.Error("serialize() with a 100-byte value returned no error")
+	}
+}
+
+// TestPayloadBuildAppendsValidChecksum checks that Build's output ends with
+// tag 63 holding a 4-hex-digit CRC that matches crc16CCITTFalse applied to
+// everything before it, and that the payload is well-formed TLV (every
+// field's claimed length accounts for the bytes that follow it).
+func TestPayloadBuildAppendsValidChecksum(t *testing.T) {
+	p := Payload{
+		MerchantAccountInformation: Field{ID: "29", Children: []Field{
+			{ID: "00", Value: "A000000677010111"},
+			{ID: "01", Value: "006681234567"},
+		}},
+		MerchantCategoryCode: "0000",
+		TransactionCurrency:  "764",
+		CountryCode:          "TH",
+		MerchantName:         "Jane",
+		MerchantCity:         "Bangkok",
+	}
+	payload, err := p.Build()
+	if err != nil {
+		t.Fatalf("Build() returned an error: %v", err)
+	}
+	if len(payload) < 8 || !strings.Contains(payload, "6304") {
+		t.Fatalf("Build() output %q has no tag 63 checksum field", payload)
+	}
+	withoutChecksum := payload[:len(payload)-4]
+	wantChecksum := crc16CCITTFalse([]byte(withoutChecksum))
+	gotChecksum := payload[len(payload)-4:]
+	if wantHex := formatHex4(wantChecksum); gotChecksum != wantHex {
+		t.Errorf("checksum field = %q, want %q (recomputed over the preceding %d bytes)", gotChecksum, wantHex, len(withoutChecksum))
+	}
+	if !strings.HasPrefix(payload, "000201") {
+		t.Errorf("Build() output %q does not start with the mandatory payload format indicator", payload)
+	}
+}
+
+// TestPayloadBuildRejectsBadFixedWidthFields checks that Build validates
+// MerchantCategoryCode, TransactionCurrency, and CountryCode are exactly
+// the digit/letter counts EMVCo mandates, rather than emitting a payload a
+// scanner will misparse.
+func TestPayloadBuildRejectsBadFixedWidthFields(t *testing.T) {
+	base := Payload{
+		MerchantAccountInformation: Field{ID: "29", Value: "x"},
+		MerchantCategoryCode:       "0000",
+		TransactionCurrency:        "764",
+		CountryCode:                "TH",
+		MerchantName:               "Jane",
+		MerchantCity:               "Bangkok",
+	}
+
+	p := base
+	p.MerchantCategoryCode = "123"
+	if _, err := p.Build(); err == nil {
+		t.Error("Build() with a 3-digit MerchantCategoryCode returned no error")
+	}
+
+	p = base
+	p.TransactionCurrency = "76"
+	if _, err := p.Build(); err == nil {
+		t.Error("Build() with a 2-digit TransactionCurrency returned no error")
+	}
+
+	p = base
+	p.CountryCode = "THA"
+	if _, err := p.Build(); err == nil {
+		t.Error("Build() with a 3-letter CountryCode returned no error")
+	}
+}
+
+func formatHex4(v uint16) string {
+	const hexDigits = "0123456789ABCDEF"
+	return string([]byte{
+		hexDigits[(v>>12)&0xF],
+		hexDigits[(v>>8)&0xF],
+		hexDigits[(v>>4)&0xF],
+		hexDigits[v&0xF],
+	})
+}
@@ -0,0 +1,97 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package emvqr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// promptPayAID is the Application ID Thailand's PromptPay network
+// registers its merchant account information template under.
+const promptPayAID = "A000000677010111"
+
+// PromptPayMobile builds a PromptPay payload that targets the account
+// linked to a mobile number, the most common PromptPay proxy type.
+//
+// mobileNumber accepts either local format ("0812345678") or
+// international format ("+66812345678" or "66812345678"); amount is the
+// decimal transaction amount (see Payload.TransactionAmount), and may be
+// empty for a reusable, any-amount code.
+func PromptPayMobile(mobileNumber, amount, merchantName, merchantCity string) (string, error) {
+	target, err := normalizeThaiMobile(mobileNumber)
+	if err != nil {
+		return "", err
+	}
+	return promptPayPayload(Field{ID: "01", Value: target}, amount, merchantName, merchantCity)
+}
+
+// PromptPayNationalID builds a PromptPay payload that targets the account
+// linked to a Thai national ID or tax ID, nationalID, a 13-digit numeric
+// string.
+func PromptPayNationalID(nationalID, amount, merchantName, merchantCity string) (string, error) {
+	if len(nationalID) != 13 || !isAllDigits(nationalID) {
+		return "", fmt.Errorf("emvqr: PromptPay national ID %q must be exactly 13 digits", nationalID)
+	}
+	return promptPayPayload(Field{ID: "02", Value: nationalID}, amount, merchantName, merchantCity)
+}
+
+func promptPayPayload(target Field, amount, merchantName, merchantCity string) (string, error) {
+	p := Payload{
+		MerchantAccountInformation: Field{
+			ID: "29",
+			Children: []Field{
+				{ID: "00", Value: promptPayAID},
+				target,
+			},
+		},
+		MerchantCategoryCode: "0000",
+		TransactionCurrency:  "764", // ISO 4217: Thai baht
+		TransactionAmount:    amount,
+		CountryCode:          "TH",
+		MerchantName:         merchantName,
+		MerchantCity:         merchantCity,
+	}
+	if amount != "" {
+		p.PointOfInitiationMethod = "12"
+	}
+	return p.Build()
+}
+
+// normalizeThaiMobile converts a Thai mobile number in local ("0XXXXXXXXX")
+// or international ("+66XXXXXXXXX" / "66XXXXXXXXX") format into the
+// "0066" + 9-digit national number form PromptPay's mobile proxy target
+// requires.
+func normalizeThaiMobile(number string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, number)
+
+	var national string
+	switch {
+	case len(digits) == 10 && digits[0] == '0':
+		national = digits[1:]
+	case len(digits) == 11 && strings.HasPrefix(digits, "66"):
+		national = digits[2:]
+	default:
+		return "", fmt.Errorf("emvqr: %q is not a recognized Thai mobile number", number)
+	}
+	return "0066" + national, nil
+}
+
+func isAllDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package emvqr
+
+import "fmt"
+
+// qrisAID is the reverse-domain Application ID Indonesia's QRIS network
+// registers its merchant account information template under.
+const qrisAID = "ID.CO.QRIS.WWW"
+
+// QRISMerchantCriteria names the four merchant size tiers the QRIS
+// specification defines, used in QRISStatic's merchantCriteria argument.
+type QRISMerchantCriteria string
+
+const (
+	QRISMicro  QRISMerchantCriteria = "UMI"
+	QRISSmall  QRISMerchantCriteria = "UKE"
+	QRISMedium QRISMerchantCriteria = "UKM"
+	QRISLarge  QRISMerchantCriteria = "UBE"
+)
+
+// QRISStatic builds a reusable, any-amount QRIS payload, the domestic
+// Indonesian profile built on the "National Merchant ID" template.
+//
+// This covers the fields needed for a basic static merchant code; the full
+// QRIS specification defines additional optional templates (e.g. consumer
+// or cross-border acquirer data) that this function does not attempt to
+// cover.
+func QRISStatic(nationalMerchantID string, criteria QRISMerchantCriteria, merchantCategoryCode, merchantName, merchantCity string) (string, error) {
+	if len(nationalMerchantID) != 15 || !isAllDigits(nationalMerchantID) {
+		return "", fmt.Errorf("emvqr: QRIS national merchant ID %q must be exactly 15 digits", nationalMerchantID)
+	}
+
+	p := Payload{
+		MerchantAccountInformation: Field{
+			ID: "51",
+			Children: []Field{
+				{ID: "00", Value: qrisAID},
+				{ID: "02", Value: nationalMerchantID},
+				{ID: "03", Value: string(criteria)},
+			},
+		},
+		MerchantCategoryCode: merchantCategoryCode,
+		TransactionCurrency:  "360", // ISO 4217: Indonesian rupiah
+		CountryCode:          "ID",
+		MerchantName:         merchantName,
+		MerchantCity:         merchantCity,
+	}
+	return p.Build()
+}
@@ -0,0 +1,29 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import "unicode/utf8"
+
+// EncodeAuto returns a QR Code representing data at the given error
+// correction level, like EncodeText or EncodeBinary, but chooses between
+// them itself: if data is valid UTF-8, it is encoded as text (letting
+// EncodeText pick numeric, alphanumeric, or byte segmentation as
+// appropriate); otherwise it is encoded as raw binary data.
+//
+// The second return value reports which path was taken (true for text,
+// false for binary), so a caller passing arbitrary []byte payloads (e.g.
+// from a file or network message) can tell which symbol it got without
+// re-running the UTF-8 check itself.
+func EncodeAuto(data []byte, ecl QrCodeEcc) (*QrCode, bool, error) {
+	if utf8.Valid(data) {
+		qr, err := EncodeText(string(data), ecl)
+		return qr, true, err
+	}
+	qr, err := EncodeBinary(data, ecl)
+	return qr, false, err
+}
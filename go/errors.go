@@ -0,0 +1,184 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nayuki/qrcodegen/qrsegment"
+)
+
+// ErrInvalidCharacter reports that a string passed to a mode-specific
+// segment constructor contains a character outside the set that mode can
+// encode. It is qrsegment.ErrInvalidCharacter, aliased here so callers of
+// this package's functions don't need to import qrsegment just to use
+// errors.As with it.
+type ErrInvalidCharacter = qrsegment.ErrInvalidCharacter
+
+// ErrVersionRange reports that EncodeSegmentsAdvanced or
+// EncodeSegmentsAdvancedContext was called with minVersion > maxVersion.
+type ErrVersionRange struct {
+	MinVersion Version
+	MaxVersion Version
+}
+
+func (e *ErrVersionRange) Error() string {
+	return fmt.Sprintf("qrcodegen: minVersion (%d) is greater than maxVersion (%d)", e.MinVersion, e.MaxVersion)
+}
+
+// ErrControlCharacter reports that EncodeTextWithOptions rejected text
+// because it contains an ASCII control character while opts.Strictness is
+// Strict.
+type ErrControlCharacter struct {
+	// Pos is the byte offset of Rune within the rejected text.
+	Pos int
+
+	// Rune is the control character itself.
+	Rune rune
+}
+
+func (e *ErrControlCharacter) Error() string {
+	return fmt.Sprintf("qrcodegen: control character %U at byte offset %d is not allowed in strict mode", e.Rune, e.Pos)
+}
+
+// ErrSegmentTooLong is the Unwrap cause of an ErrDataTooLong whose
+// SegmentTooLong field is true: a segment's character count doesn't fit its
+// mode's length field at all, so no version could ever hold it. Match it
+// with errors.Is.
+var ErrSegmentTooLong = errors.New("qrcodegen: segment too long for its mode's character count field")
+
+// ErrCapacityExceeded is the Unwrap cause of an ErrDataTooLong whose
+// SegmentTooLong field is false: the segments fit their mode's length
+// fields, but need more bits than MaxVersionTried holds at
+// ErrorCorrectionLevel. Match it with errors.As to read its fields without
+// going through ErrDataTooLong's SegmentTooLong/RequiredBits/CapacityBits.
+type ErrCapacityExceeded struct {
+	RequiredBits         int
+	CapacityBits         int
+	MaxVersionTried      Version
+	ErrorCorrectionLevel QrCodeEcc
+}
+
+func (e *ErrCapacityExceeded) Error() string {
+	return fmt.Sprintf("qrcodegen: data length = %d bits, max capacity at version %d (%s) = %d bits",
+		e.RequiredBits, e.MaxVersionTried, e.ErrorCorrectionLevel, e.CapacityBits)
+}
+
+// ErrDataTooLong reports that the supplied data does not fit any QR Code
+// version in the requested range at the requested error correction level.
+//
+// Unlike a plain formatted error, its fields let a caller programmatically
+// suggest a fix, such as "lower the error correction level to Medium" or
+// "raise maxVersion to 9", instead of just displaying the message.
+//
+// Ways to handle this error include:
+//
+//   - Decrease the error correction level if it was greater than Low.
+//   - If EncodeSegmentsAdvanced was called, increase the maxVersion argument
+//     if it was less than MaxVersion. (This advice does not apply to the
+//     other factory functions because they search all versions up to
+//     MaxVersion.)
+//   - Split the text data into better or optimal segments to reduce the
+//     number of bits required.
+//   - Change the text or binary data to be shorter.
+//   - Change the text to fit the character set of a particular segment mode
+//     (e.g. alphanumeric).
+//   - Propagate the error upward to the caller/user.
+//
+// Unwrap returns the more specific cause, ErrSegmentTooLong (match with
+// errors.Is) or *ErrCapacityExceeded (match with errors.As), for callers
+// who only care which case occurred and not the suggestion fields below.
+type ErrDataTooLong struct {
+	// SegmentTooLong is true if a segment's character count doesn't fit its
+	// mode's length field at all, in which case RequiredBits and
+	// CapacityBits are both zero.
+	SegmentTooLong bool
+
+	// RequiredBits is the number of bits the segments would need at
+	// MaxVersionTried, valid only when SegmentTooLong is false.
+	RequiredBits int
+
+	// CapacityBits is the data capacity, in bits, of MaxVersionTried at
+	// ErrorCorrectionLevel. Valid only when SegmentTooLong is false.
+	CapacityBits int
+
+	// MaxVersionTried is the largest version number that was attempted
+	// before giving up (i.e. the maxVersion argument that was passed in).
+	MaxVersionTried Version
+
+	// ErrorCorrectionLevel is the level that was requested (or boosted to,
+	// though boosting never happens when encoding fails).
+	ErrorCorrectionLevel QrCodeEcc
+
+	// HasSuggestion is true if SuggestedVersion/SuggestedEcl identify a
+	// version/ECC combination (searched across the entire valid version
+	// range, ignoring the caller's maxVersion) that would fit the data.
+	HasSuggestion    bool
+	SuggestedVersion Version
+	SuggestedEcl     QrCodeEcc
+}
+
+func (e *ErrDataTooLong) Error() string {
+	var msg string
+	if e.SegmentTooLong {
+		msg = "qrcodegen: segment too long for its mode's character count field"
+	} else {
+		msg = fmt.Sprintf("qrcodegen: data length = %d bits, max capacity at version %d (%s) = %d bits",
+			e.RequiredBits, e.MaxVersionTried, e.ErrorCorrectionLevel, e.CapacityBits)
+	}
+	if e.HasSuggestion {
+		msg += fmt.Sprintf("; would fit at version %d with ECC level %s", e.SuggestedVersion, e.SuggestedEcl)
+	}
+	return msg
+}
+
+// Unwrap lets errors.Is(err, ErrSegmentTooLong) and
+// errors.As(err, &capacityErr) match this error's specific cause directly.
+func (e *ErrDataTooLong) Unwrap() error {
+	if e.SegmentTooLong {
+		return ErrSegmentTooLong
+	}
+	return &ErrCapacityExceeded{
+		RequiredBits:         e.RequiredBits,
+		CapacityBits:         e.CapacityBits,
+		MaxVersionTried:      e.MaxVersionTried,
+		ErrorCorrectionLevel: e.ErrorCorrectionLevel,
+	}
+}
+
+// newErrDataTooLong builds an ErrDataTooLong for a failed encode attempt,
+// additionally searching the entire valid version range (independent of the
+// caller's maxVersion) for a version/ECC combination that would have fit.
+func newErrDataTooLong(segs []*qrsegment.QrSegment, fitsField bool, dataUsed, dataCapacityBits int, maxVersionTried Version, ecl QrCodeEcc) *ErrDataTooLong {
+	err := &ErrDataTooLong{
+		SegmentTooLong:       !fitsField,
+		MaxVersionTried:      maxVersionTried,
+		ErrorCorrectionLevel: ecl,
+	}
+	if fitsField {
+		err.RequiredBits = dataUsed
+		err.CapacityBits = dataCapacityBits
+	}
+
+	for v := MinVersion; v <= MaxVersion && !err.HasSuggestion; v++ {
+		used, ok := qrsegment.GetTotalBits(segs, v)
+		if !ok {
+			continue
+		}
+		for _, e := range []QrCodeEcc{Low, Medium, Quartile, High} {
+			if used <= getNumDataCodewords(v, e)*8 {
+				err.HasSuggestion = true
+				err.SuggestedVersion = v
+				err.SuggestedEcl = e
+				break
+			}
+		}
+	}
+	return err
+}
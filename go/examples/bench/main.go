@@ -0,0 +1,113 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Command bench times the library's main cost centers: encoding at a small,
+// medium, and large version, mask selection, Reed-Solomon error correction,
+// and PNG rendering. This module has no go test -bench targets; this
+// program is the standalone equivalent, run with `go run ./examples/bench`.
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	qr "github.com/nayuki/qrcodegen"
+	"github.com/nayuki/qrcodegen/render"
+	"github.com/nayuki/qrcodegen/rs"
+)
+
+// run calls f n times and reports the mean duration per call, in the same
+// spirit as a testing.B loop but without depending on a _test.go file.
+func run(name string, n int, f func()) {
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		f()
+	}
+	elapsed := time.Since(start)
+	fmt.Printf("%-24s %10s/op  (%d iterations)\n", name, elapsed/time.Duration(n), n)
+}
+
+func main() {
+	benchEncodeText("EncodeText_V1", qr.Version(1), 2000)
+	benchEncodeText("EncodeText_V10", qr.Version(10), 500)
+	benchEncodeText("EncodeText_V40", qr.Version(40), 50)
+	benchAutoMask()
+	benchRS()
+	benchRenderPNG()
+}
+
+// benchEncodeText times EncodeText on a payload sized to just fit ver at
+// the Low error correction level, the same way the library's own version
+// search would arrive at ver.
+func benchEncodeText(name string, ver qr.Version, n int) {
+	capacityBits := qr.DataCapacityBits(ver, qr.Low)
+	text := make([]byte, 0, capacityBits/8)
+	// Byte mode costs 8 bits per character plus at most 20 bits of mode and
+	// count header, so 3 bytes short of capacity fits ver but not ver-1.
+	for len(text) < capacityBits/8-3 {
+		text = append(text, 'a')
+	}
+	payload := string(text)
+	run(name, n, func() {
+		if _, err := qr.EncodeText(payload, qr.Low); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	})
+}
+
+// benchAutoMask times the 8-mask evaluation that EncodeCodewords runs when
+// no mask is forced, at version 10 where the grid is large enough to show
+// masking's cost relative to the rest of the pipeline.
+func benchAutoMask() {
+	ver := qr.Version(10)
+	dataCodewords := make([]byte, qr.DataCapacityBits(ver, qr.Medium)/8)
+	run("AutoMask_V10", 500, func() {
+		qr.EncodeCodewords(ver, qr.Medium, dataCodewords, nil)
+	})
+}
+
+// benchRS times Reed-Solomon error correction on one block of a version-10
+// symbol at the highest error correction level the payload fits (High for
+// this short payload), with no errors to correct, the common case for a
+// clean scan.
+func benchRS() {
+	q, err := qr.EncodeTextWithOptions("benchmark payload for Reed-Solomon correction", qr.Medium, qr.EncodeTextOptions{
+		Strategy: qr.MaxEclAtVersion(qr.Version(10)),
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	block := q.EccBlocks()[0]
+	codeword := append(append([]byte{}, block.Data...), block.Ecc...)
+	run("RS_Correct", 5000, func() {
+		if _, err := rs.Correct(codeword, len(block.Ecc)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	})
+}
+
+// benchRenderPNG times rasterizing a version-10 symbol to PNG at a modest
+// scale, representative of the per-request cost in an HTTP handler.
+func benchRenderPNG() {
+	q, err := qr.EncodeTextWithOptions("benchmark payload for PNG rendering", qr.Medium, qr.EncodeTextOptions{
+		Strategy: qr.MaxEclAtVersion(qr.Version(10)),
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	run("Render_PNG_V10", 500, func() {
+		if _, err := render.ToPNG(q, 6, 4, render.PNGOptions{}); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Command decodetext demonstrates round-tripping a QR Code: encoding text,
+// then recovering it with QrCode.DecodeText, the way a scanner's output
+// would be fed back through the library.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	qr "github.com/nayuki/qrcodegen"
+)
+
+func main() {
+	q, err := qr.EncodeText("Round-trip me", qr.Quartile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	text, err := q.DecodeText()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println(text)
+}
@@ -0,0 +1,28 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Command encodetext is a minimal example of turning text into a QR Code
+// and printing it as terminal text art, using only the library's public
+// API: QrCode.ToTextArt already does the character-grid assembly that
+// other language ports' demos do by hand.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	qr "github.com/nayuki/qrcodegen"
+)
+
+func main() {
+	q, err := qr.EncodeText("Hello, world!", qr.Medium)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println(q.ToTextArt(4))
+}
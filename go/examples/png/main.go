@@ -0,0 +1,36 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Command png demonstrates writing a QR Code out as a PNG file via the
+// render package, which handles the pixel rasterization that other
+// language ports' demos otherwise duplicate by hand.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	qr "github.com/nayuki/qrcodegen"
+	"github.com/nayuki/qrcodegen/render"
+)
+
+func main() {
+	q, err := qr.EncodeText("https://www.nayuki.io/", qr.High)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	png, err := render.ToPNG(q, 10, 4, render.PNGOptions{})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile("qrcode.png", png, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Command tui is an interactive preview loop for experimenting with how
+// payload text, error correction level, version, and mask affect a
+// symbol's size and appearance, using QrCode.ToTextArt.
+//
+// It re-renders after every line of input rather than every keystroke: Go's
+// standard library has no portable way to read raw keystrokes without a
+// platform-specific terminal dependency, which this module otherwise has
+// none of. A plain line of input sets the payload text; a line starting
+// with ":" is a command. Type ":help" for the command list, or ":quit" to
+// exit.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	qr "github.com/nayuki/qrcodegen"
+	"github.com/nayuki/qrcodegen/qrsegment"
+)
+
+// previewState holds everything tui needs to re-render: the payload text
+// and the knobs toggled via ":ecc", ":version", and ":mask" commands.
+type previewState struct {
+	text    string
+	ecl     qr.QrCodeEcc
+	version qr.Version // 0 means automatic
+	mask    int32      // -1 means automatic
+}
+
+func main() {
+	state := previewState{ecl: qr.Low, mask: -1}
+	printHelp()
+	render(state)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("> ")
+		if !scanner.Scan() {
+			break
+		}
+		line := scanner.Text()
+		switch {
+		case line == "":
+			continue
+		case line == ":quit" || line == ":exit":
+			return
+		case line == ":help":
+			printHelp()
+			continue
+		case strings.HasPrefix(line, ":ecc "):
+			if err := setEcc(&state, strings.TrimSpace(line[len(":ecc "):])); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				continue
+			}
+		case strings.HasPrefix(line, ":version "):
+			if err := setVersion(&state, strings.TrimSpace(line[len(":version "):])); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				continue
+			}
+		case strings.HasPrefix(line, ":mask "):
+			if err := setMask(&state, strings.TrimSpace(line[len(":mask "):])); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				continue
+			}
+		case strings.HasPrefix(line, ":"):
+			fmt.Fprintf(os.Stderr, "unknown command %q; type :help\n", line)
+			continue
+		default:
+			state.text = line
+		}
+		render(state)
+	}
+}
+
+func printHelp() {
+	fmt.Println("Commands:")
+	fmt.Println("  <text>           set the payload text and re-render")
+	fmt.Println("  :ecc low|medium|quartile|high")
+	fmt.Println("  :version auto|1..40")
+	fmt.Println("  :mask auto|0..7")
+	fmt.Println("  :help")
+	fmt.Println("  :quit")
+}
+
+func setEcc(state *previewState, arg string) error {
+	switch strings.ToLower(arg) {
+	case "low":
+		state.ecl = qr.Low
+	case "medium":
+		state.ecl = qr.Medium
+	case "quartile":
+		state.ecl = qr.Quartile
+	case "high":
+		state.ecl = qr.High
+	default:
+		return fmt.Errorf("unknown error correction level %q", arg)
+	}
+	return nil
+}
+
+func setVersion(state *previewState, arg string) error {
+	if strings.ToLower(arg) == "auto" {
+		state.version = 0
+		return nil
+	}
+	n, err := strconv.Atoi(arg)
+	if err != nil || qr.Version(n) < qr.MinVersion || qr.Version(n) > qr.MaxVersion {
+		return fmt.Errorf("version must be %q or %d..%d", "auto", qr.MinVersion, qr.MaxVersion)
+	}
+	state.version = qr.Version(n)
+	return nil
+}
+
+func setMask(state *previewState, arg string) error {
+	if strings.ToLower(arg) == "auto" {
+		state.mask = -1
+		return nil
+	}
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 0 || n > 7 {
+		return fmt.Errorf("mask must be %q or 0..7", "auto")
+	}
+	state.mask = int32(n)
+	return nil
+}
+
+// render builds a QR Code from state and prints it as text art, or prints
+// why it couldn't.
+func render(state previewState) {
+	minVersion, maxVersion := qr.MinVersion, qr.MaxVersion
+	if state.version != 0 {
+		minVersion, maxVersion = state.version, state.version
+	}
+	var msk *qr.Mask
+	if state.mask >= 0 {
+		m := qr.Mask(state.mask)
+		msk = &m
+	}
+
+	segs := qrsegment.MakeSegments(state.text)
+	q, err := qr.EncodeSegmentsAdvanced(segs, state.ecl, minVersion, maxVersion, msk, true)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("version=%d ecl=%s mask=%d size=%d\n", q.Version(), q.ErrorCorrectionLevel(), q.GetMask(), q.Size())
+	fmt.Println(q.ToTextArt(2))
+}
@@ -0,0 +1,58 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Package experimental is a sandbox for researching non-standard QR Code
+// mask patterns: building symbols with caller-defined mask predicates and
+// scoring the result with the standard penalty rules, for comparing
+// candidate masking heuristics against the scores ISO/IEC 18004's own 8
+// masks would get.
+//
+// Symbols built here are not guaranteed to be decodable by standard-
+// conforming readers; see BuildWithMask's doc comment. Nothing in this
+// package is used by, or affects, qrcodegen's standard encoding path.
+package experimental
+
+import (
+	"github.com/nayuki/qrcodegen"
+	"github.com/nayuki/qrcodegen/mask"
+	"github.com/nayuki/qrcodegen/penalty"
+)
+
+// BuildWithMask builds a QR Code symbol with the given version, error
+// correction level, and data codewords, applying maskFunc instead of one of
+// the 8 standard mask patterns. It is a thin wrapper around
+// qrcodegen.EncodeCodewordsExperimentalMask, which carries the full warning:
+// the format bits can only record a standard mask value, so a symbol built
+// this way writes a placeholder and is NOT guaranteed to be decodable by
+// standard-conforming readers. Do not use this for symbols that need to be
+// scanned by third-party software.
+func BuildWithMask(ver qrcodegen.Version, ecl qrcodegen.QrCodeEcc, dataCodewords []byte, maskFunc mask.Predicate) *qrcodegen.QrCode {
+	return qrcodegen.EncodeCodewordsExperimentalMask(ver, ecl, dataCodewords, maskFunc)
+}
+
+// ScorePenalty evaluates qr's modules in their final, masked state (the
+// same state a scanner reads) against the standard N1-N4 penalty rules, so
+// a caller-defined mask's score can be compared against the 8 standard
+// masks' own scores (e.g. via qrcodegen.EncodeCodewordsAllMasks).
+func ScorePenalty(qr *qrcodegen.QrCode) penalty.Report {
+	return penalty.Analyze(moduleGrid(qr))
+}
+
+// moduleGrid reads every module of qr into the [][]bool layout penalty.Analyze
+// expects, grid[y][x] with true meaning dark.
+func moduleGrid(qr *qrcodegen.QrCode) [][]bool {
+	size := qr.Size()
+	rows := make([][]bool, size)
+	for y := int32(0); y < size; y++ {
+		row := make([]bool, size)
+		for x := int32(0); x < size; x++ {
+			row[x] = qr.GetModule(x, y)
+		}
+		rows[y] = row
+	}
+	return rows
+}
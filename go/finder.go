@@ -0,0 +1,131 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FinderShape selects how ToSvgStringWithOptions draws the three finder-eye
+// patterns when SvgOptions.FinderColor or SvgOptions.FinderShape requests
+// styling distinct from ordinary data modules.
+type FinderShape int
+
+const (
+	// FinderSquare draws each finder eye as the plain nested squares that
+	// the symbol is already composed of (7x7 ring, 5x5 gap, 3x3 center),
+	// just in FinderColor instead of the data module color.
+	FinderSquare FinderShape = iota
+
+	// FinderRounded draws each finder eye as a rounded-corner outer ring
+	// around a circular center dot, a common "branding" treatment that
+	// most scanners still tolerate because it preserves the ring's dark
+	// area and the dot's position relative to the symbol's timing
+	// patterns.
+	FinderRounded
+)
+
+// finderEyeCenters returns the module coordinates of the centers of this QR
+// Code's three finder eyes (top-left, top-right, bottom-left), matching the
+// positions drawn by drawFinderPattern.
+func (q *QrCode) finderEyeCenters() [3][2]int32 {
+	return [3][2]int32{
+		{3, 3},
+		{q.size - 4, 3},
+		{3, q.size - 4},
+	}
+}
+
+// isFinderEyeModule reports whether (x, y) lies within the 7x7 square-in-
+// square pattern of one of this QR Code's three finder eyes, i.e. excluding
+// the 1-module white separator ring that drawFinderPattern also draws
+// around it.
+func (q *QrCode) isFinderEyeModule(x, y int32) bool {
+	for _, c := range q.finderEyeCenters() {
+		if abs32(x-c[0]) <= 3 && abs32(y-c[1]) <= 3 {
+			return true
+		}
+	}
+	return false
+}
+
+// finderEyesSvg returns SVG markup drawing this QR Code's three finder eyes
+// in the given shape and fill color, offset by border. It is meant to be
+// layered on top of a data path that has excluded finder-eye modules (see
+// svgPathDataExcludingFinders), so that the eyes are not also drawn as
+// ordinary 1x1 module rectangles.
+func (q *QrCode) finderEyesSvg(border int32, shape FinderShape, color string) string {
+	color = xmlEscapeAttr(color)
+	var result string
+	for _, c := range q.finderEyeCenters() {
+		x, y := c[0]+border, c[1]+border
+		switch shape {
+		case FinderRounded:
+			result += fmt.Sprintf("<rect x=\"%d\" y=\"%d\" width=\"7\" height=\"7\" rx=\"1.5\" fill=\"%s\"/>", x-3, y-3, color)
+			result += fmt.Sprintf("<rect x=\"%d\" y=\"%d\" width=\"5\" height=\"5\" rx=\"1\" fill=\"#FFFFFF\"/>", x-2, y-2)
+			result += fmt.Sprintf("<circle cx=\"%.1f\" cy=\"%.1f\" r=\"1.5\" fill=\"%s\"/>", float64(x)+0.5, float64(y)+0.5, color)
+		default: // FinderSquare
+			result += fmt.Sprintf("<rect x=\"%d\" y=\"%d\" width=\"7\" height=\"7\" fill=\"%s\"/>", x-3, y-3, color)
+			result += fmt.Sprintf("<rect x=\"%d\" y=\"%d\" width=\"5\" height=\"5\" fill=\"#FFFFFF\"/>", x-2, y-2)
+			result += fmt.Sprintf("<rect x=\"%d\" y=\"%d\" width=\"3\" height=\"3\" fill=\"%s\"/>", x-1, y-1, color)
+		}
+	}
+	return result
+}
+
+// xmlEscapeAttr escapes the characters that are special inside a
+// double-quoted SVG/XML attribute value, for a caller-supplied string
+// (e.g. FinderColor) interpolated into one. Unlike plain element text
+// content, an attribute value must also escape the double quote itself,
+// since an unescaped one lets the value break out of the attribute and
+// inject arbitrary markup.
+func xmlEscapeAttr(s string) string {
+	var buf strings.Builder
+	for _, r := range s {
+		switch r {
+		case '&':
+			buf.WriteString("&amp;")
+		case '<':
+			buf.WriteString("&lt;")
+		case '>':
+			buf.WriteString("&gt;")
+		case '"':
+			buf.WriteString("&quot;")
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
+
+// svgPathDataExcludingFinders behaves like svgPathData, but omits any dark
+// module that belongs to a finder eye, for use alongside finderEyesSvg.
+func (q *QrCode) svgPathDataExcludingFinders(border int32) string {
+	var result string
+	first := true
+	for y := int32(0); y < q.size; y++ {
+		for x := int32(0); x < q.size; {
+			if !q.GetModule(x, y) || q.isFinderEyeModule(x, y) {
+				x++
+				continue
+			}
+			runStart := x
+			for x < q.size && q.GetModule(x, y) && !q.isFinderEyeModule(x, y) {
+				x++
+			}
+			runLen := x - runStart
+			if !first {
+				result += " "
+			}
+			first = false
+			result += fmt.Sprintf("M%d,%dh%dv1h-%dz", runStart+border, y+border, runLen, runLen)
+		}
+	}
+	return result
+}
@@ -0,0 +1,34 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestFinderColorAttributeInjection checks that a FinderColor value
+// containing a double quote can't break out of the "fill" attribute it's
+// interpolated into and inject arbitrary SVG markup or attributes.
+func TestFinderColorAttributeInjection(t *testing.T) {
+	qr, err := EncodeText("attribute injection test", Medium)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const payload = `red" onload="alert(1)" x="`
+	svg, err := qr.ToSvgStringWithOptionsChecked(4, SvgOptions{FinderColor: payload})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(svg, payload) {
+		t.Errorf("ToSvgStringWithOptionsChecked did not escape FinderColor; output contains the raw, unescaped payload, which breaks out of the fill attribute: %q", svg)
+	}
+	if !strings.Contains(svg, "&quot;") {
+		t.Errorf("ToSvgStringWithOptionsChecked output has no escaped quote, so the payload's embedded \" was not neutralized: %q", svg)
+	}
+}
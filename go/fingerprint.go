@@ -0,0 +1,54 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import "crypto/sha256"
+
+// Fingerprint returns a stable SHA-256 hash over q's version, error
+// correction level, mask, and module grid. Two QrCode values built from the
+// same inputs always produce the same Fingerprint, making it a good cache
+// key or dedupe key for identifying identical symbols, unlike hashing a
+// rendered image's bytes, which varies with the renderer's own
+// non-determinism (PNG compression level, embedded timestamps, etc.).
+//
+// Fingerprint says nothing about any out-of-band presentation a caller
+// might layer on top of the symbol, such as a quiet zone, color, or center
+// label; it only covers the module grid EncodeText and friends produce.
+func (q *QrCode) Fingerprint() [32]byte {
+	h := sha256.New()
+	h.Write([]byte{byte(q.version), byte(q.errorCorrectionLevel), byte(q.mask)})
+	packed := make([]byte, (len(q.modules)+7)/8)
+	for i, b := range q.modules {
+		if b {
+			packed[i>>3] |= 1 << uint(i&7)
+		}
+	}
+	h.Write(packed)
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// QuickNotEqual reports whether q and other are definitely not the same
+// symbol, checked using only their O(1) metadata (version, error
+// correction level, and mask) without touching either module grid. It
+// never reports true for two symbols that actually are equal, so a caller
+// can use it to skip a full Fingerprint comparison:
+//
+//	if !a.QuickNotEqual(b) && a.Fingerprint() == b.Fingerprint() {
+//		// a and b are the same symbol
+//	}
+//
+// A false result does not imply the two symbols are equal: distinct
+// payloads routinely land on the same version, error correction level, and
+// mask by coincidence.
+func (q *QrCode) QuickNotEqual(other *QrCode) bool {
+	return q.version != other.version ||
+		q.errorCorrectionLevel != other.errorCorrectionLevel ||
+		q.mask != other.mask
+}
@@ -0,0 +1,83 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import "fmt"
+
+// Point is a module coordinate, zero-based from the symbol's top-left
+// corner, in the same coordinate space as GetModule's x, y arguments.
+type Point struct {
+	X, Y int32
+}
+
+// GridBuilder assembles a square module grid for DecodeModules, or for any
+// other tool (a decoder, a test fixture, a damage simulator) that wants to
+// set individual modules by coordinate instead of hand-indexing a raw
+// [][]bool and risking a transposed X/Y access.
+//
+// The zero value is not usable; create one with NewGridBuilder.
+type GridBuilder struct {
+	size int32
+	rows [][]bool
+}
+
+// NewGridBuilder returns a GridBuilder for a size-by-size grid, with every
+// module initially light.
+//
+// Panics if size is not positive.
+func NewGridBuilder(size int32) *GridBuilder {
+	if size <= 0 {
+		panic("size must be positive")
+	}
+	rows := make([][]bool, size)
+	for i := range rows {
+		rows[i] = make([]bool, size)
+	}
+	return &GridBuilder{size: size, rows: rows}
+}
+
+// Size returns the side length passed to NewGridBuilder.
+func (g *GridBuilder) Size() int32 {
+	return g.size
+}
+
+// Set marks the module at p dark or light.
+//
+// Returns an error if p falls outside [0, Size()) on either axis, instead
+// of panicking, so a tool working from untrusted or miscalculated
+// coordinates (e.g. from a damaged scan) can report the bad input rather
+// than crash.
+func (g *GridBuilder) Set(p Point, dark bool) error {
+	if p.X < 0 || p.X >= g.size || p.Y < 0 || p.Y >= g.size {
+		return fmt.Errorf("qrcodegen: point %v out of range [0,%d)", p, g.size)
+	}
+	g.rows[p.Y][p.X] = dark
+	return nil
+}
+
+// Get returns the current value of the module at p.
+//
+// Returns an error if p falls outside [0, Size()) on either axis.
+func (g *GridBuilder) Get(p Point) (bool, error) {
+	if p.X < 0 || p.X >= g.size || p.Y < 0 || p.Y >= g.size {
+		return false, fmt.Errorf("qrcodegen: point %v out of range [0,%d)", p, g.size)
+	}
+	return g.rows[p.Y][p.X], nil
+}
+
+// Grid returns the assembled grid in the [][]bool layout DecodeModules
+// expects, i.e. grid[y][x]. The returned rows are copies, so mutating them
+// afterward does not affect this GridBuilder.
+func (g *GridBuilder) Grid() [][]bool {
+	grid := make([][]bool, g.size)
+	for y, row := range g.rows {
+		grid[y] = make([]bool, g.size)
+		copy(grid[y], row)
+	}
+	return grid
+}
@@ -0,0 +1,39 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Command qrgrpcserver runs the reference QrCodeGenerator gRPC service
+// defined in proto/qrcode.proto, listening on the address given by the
+// "-addr" flag.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+
+	"github.com/nayuki/qrcodegen/grpcserver"
+	"github.com/nayuki/qrcodegen/grpcserver/qrcodegenpb"
+)
+
+func main() {
+	addr := flag.String("addr", ":50051", "address to listen on")
+	flag.Parse()
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("qrgrpcserver: listen: %v", err)
+	}
+
+	s := grpc.NewServer()
+	qrcodegenpb.RegisterQrCodeGeneratorServer(s, grpcserver.New())
+	log.Printf("qrgrpcserver: listening on %s", *addr)
+	if err := s.Serve(lis); err != nil {
+		log.Fatalf("qrgrpcserver: serve: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ * - The above copyright notice and this permission notice shall be included in
+ *   all copies or substantial portions of the Software.
+ * - The Software is provided "as is", without warranty of any kind, express or
+ *   implied, including but not limited to the warranties of merchantability,
+ *   fitness for a particular purpose and noninfringement. In no event shall the
+ *   authors or copyright holders be liable for any claim, damages or other
+ *   liability, whether in an action of contract, tort or otherwise, arising from,
+ *   out of or in connection with the Software or the use or other dealings in the
+ *   Software.
+ */
+
+// Package mask defines the 8 standard QR Code mask patterns and their predicates.
+package mask
+
+import "fmt"
+
+// Mask is a number between 0 and 7 (inclusive) identifying one of the 8
+// standard QR Code mask patterns defined by the Model 2 specification.
+type Mask int32
+
+// New creates a mask value from the given number.
+//
+// Panics if the number is outside the range [0, 7]; use NewChecked if
+// value isn't already known to be in range (e.g. it came from a caller
+// or network request).
+func New(value int32) Mask {
+	m, err := NewChecked(value)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// NewChecked is like New, but returns an error instead of panicking if
+// value is outside the range [0, 7].
+func NewChecked(value int32) (Mask, error) {
+	if value < 0 || value > 7 {
+		return 0, fmt.Errorf("mask: value out of range: %d", value)
+	}
+	return Mask(value), nil
+}
+
+// Value returns the value of this mask, which is in the range [0, 7].
+func (m Mask) Value() int32 {
+	return int32(m)
+}
+
+// Invert reports whether the module at (x, y) should be inverted by this
+// mask pattern. This is exactly the predicate used internally by QrCode
+// when applying a mask to the data modules of a symbol.
+//
+// Panics if m is not a value produced by New (i.e. not in [0, 7]).
+func (m Mask) Invert(x, y int32) bool {
+	switch m {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	case 7:
+		return ((x+y)%2+x*y%3)%2 == 0
+	default:
+		panic("mask value out of range")
+	}
+}
+
+// Predicate is a function that decides, for the given module coordinates,
+// whether that module should be inverted by a mask pattern.
+type Predicate func(x, y int32) bool
+
+// Func returns m's masking predicate as a standalone Predicate value, for
+// callers that want to pass it around or compare it against a caller-supplied
+// experimental predicate.
+func (m Mask) Func() Predicate {
+	return m.Invert
+}
+
+func (m Mask) String() string {
+	return fmt.Sprintf("Mask(%d)", int32(m))
+}
@@ -0,0 +1,97 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nayuki/qrcodegen/mask"
+	"github.com/nayuki/qrcodegen/qrsegment"
+)
+
+// MaskSet restricts automatic mask selection to a subset of the 8 standard
+// masks, for a caller that knows certain masks trip up a specific scanner
+// fleet (via NewMaskSet to name the allowed masks, or ExcludeMasks to name
+// the forbidden ones instead), or that wants to narrow the search for
+// speed.
+//
+// The zero value is AllMasks: no restriction, matching the behavior of
+// EncodeSegmentsAdvanced's automatic selection (msk == nil).
+type MaskSet struct {
+	allowed    uint8 // bit i set means mask i is allowed; meaningless unless restricted
+	restricted bool
+}
+
+// AllMasks is the zero value of MaskSet: every one of the 8 standard masks
+// is eligible, the default automatic-selection behavior.
+var AllMasks MaskSet
+
+// NewMaskSet returns a MaskSet that restricts automatic selection to
+// exactly the given masks.
+//
+// Returns an error if masks is empty, or any element is out of [0, 7].
+func NewMaskSet(masks ...Mask) (MaskSet, error) {
+	if len(masks) == 0 {
+		return MaskSet{}, errors.New("qrcodegen: mask set must allow at least one mask")
+	}
+	var bits uint8
+	for _, m := range masks {
+		if _, err := mask.NewChecked(m.Value()); err != nil {
+			return MaskSet{}, fmt.Errorf("qrcodegen: invalid mask: %w", err)
+		}
+		bits |= 1 << uint(m.Value())
+	}
+	return MaskSet{allowed: bits, restricted: true}, nil
+}
+
+// ExcludeMasks returns a MaskSet that restricts automatic selection to
+// every standard mask except the given ones, for naming the few masks known
+// to trouble a scanner fleet rather than enumerating the rest.
+//
+// Returns an error if every mask is excluded, or any element is out of
+// [0, 7].
+func ExcludeMasks(masks ...Mask) (MaskSet, error) {
+	bits := uint8(0xFF)
+	for _, m := range masks {
+		if _, err := mask.NewChecked(m.Value()); err != nil {
+			return MaskSet{}, fmt.Errorf("qrcodegen: invalid mask: %w", err)
+		}
+		bits &^= 1 << uint(m.Value())
+	}
+	if bits == 0 {
+		return MaskSet{}, errors.New("qrcodegen: mask set must allow at least one mask")
+	}
+	return MaskSet{allowed: bits, restricted: true}, nil
+}
+
+// contains reports whether m is a candidate under s.
+func (s MaskSet) contains(m Mask) bool {
+	return !s.restricted || s.allowed&(1<<uint(m.Value())) != 0
+}
+
+// EncodeSegmentsAdvancedMaskSet behaves like EncodeSegmentsAdvanced, but
+// restricts automatic mask selection to masks instead of searching all 8.
+// Unlike EncodeSegmentsAdvanced's msk parameter, this always searches (it
+// cannot force a single predetermined mask); pass a MaskSet allowing
+// exactly one mask for that instead.
+//
+// Returns an *ErrVersionRange error if minVersion > maxVersion.
+func EncodeSegmentsAdvancedMaskSet(segs []*qrsegment.QrSegment, ecl QrCodeEcc, minVersion, maxVersion Version, masks MaskSet, boostEcl bool) (*QrCode, error) {
+	return EncodeSegmentsAdvancedMaskSetContext(context.Background(), segs, ecl, minVersion, maxVersion, masks, boostEcl, nil)
+}
+
+// EncodeSegmentsAdvancedMaskSetContext behaves like
+// EncodeSegmentsAdvancedContext, but restricts automatic mask selection to
+// masks instead of searching all 8.
+//
+// Returns an *ErrVersionRange error if minVersion > maxVersion.
+func EncodeSegmentsAdvancedMaskSetContext(ctx context.Context, segs []*qrsegment.QrSegment, ecl QrCodeEcc, minVersion, maxVersion Version, masks MaskSet, boostEcl bool, observer Observer) (*QrCode, error) {
+	return encodeSegmentsAdvanced(ctx, segs, ecl, minVersion, maxVersion, nil, masks, boostEcl, observer)
+}
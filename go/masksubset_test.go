@@ -0,0 +1,103 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"testing"
+
+	"github.com/nayuki/qrcodegen/qrsegment"
+)
+
+// TestEncodeSegmentsAdvancedMaskSetRestrictsChoice checks that
+// EncodeSegmentsAdvancedMaskSet only ever picks a mask that NewMaskSet was
+// given, even though a different mask might score better unrestricted.
+func TestEncodeSegmentsAdvancedMaskSetRestrictsChoice(t *testing.T) {
+	segs := []*qrsegment.QrSegment{qrsegment.MakeBytes([]byte("restrict the mask search"))}
+	for want := Mask(0); want <= 7; want++ {
+		masks, err := NewMaskSet(want)
+		if err != nil {
+			t.Fatalf("NewMaskSet(%d) returned an error: %v", want, err)
+		}
+		qr, err := EncodeSegmentsAdvancedMaskSet(segs, Medium, MinVersion, MaxVersion, masks, true)
+		if err != nil {
+			t.Fatalf("EncodeSegmentsAdvancedMaskSet(mask=%d) returned an error: %v", want, err)
+		}
+		if got := qr.GetMask(); got != want {
+			t.Errorf("EncodeSegmentsAdvancedMaskSet(mask=%d): GetMask() = %d, want %d", want, got, want)
+		}
+	}
+}
+
+// TestNewMaskSetRejectsEmpty checks that NewMaskSet with no masks (which
+// would leave automatic selection with no candidate) returns an error
+// instead of silently behaving like AllMasks.
+func TestNewMaskSetRejectsEmpty(t *testing.T) {
+	if _, err := NewMaskSet(); err == nil {
+		t.Error("NewMaskSet() with no arguments returned no error")
+	}
+}
+
+// TestExcludeMasksRejectsExcludingEverything checks that excluding all 8
+// standard masks, which would leave automatic selection with no candidate,
+// returns an error.
+func TestExcludeMasksRejectsExcludingEverything(t *testing.T) {
+	if _, err := ExcludeMasks(0, 1, 2, 3, 4, 5, 6, 7); err == nil {
+		t.Error("ExcludeMasks(0, 1, ..., 7) returned no error")
+	}
+}
+
+// TestMaskSetRejectsOutOfRangeMask checks that both constructors reject a
+// mask value outside [0, 7], matching mask.NewChecked.
+func TestMaskSetRejectsOutOfRangeMask(t *testing.T) {
+	for _, bad := range []Mask{-1, 8} {
+		if _, err := NewMaskSet(bad); err == nil {
+			t.Errorf("NewMaskSet(%d) returned no error", bad)
+		}
+		if _, err := ExcludeMasks(bad); err == nil {
+			t.Errorf("ExcludeMasks(%d) returned no error", bad)
+		}
+	}
+}
+
+// TestMaskSetContainsMatchesMaskPackage checks that MaskSet.contains agrees
+// with the masks actually passed to NewMaskSet/ExcludeMasks, independent of
+// encodeCodewords, covering the bit-packing in allowed directly.
+func TestMaskSetContainsMatchesMaskPackage(t *testing.T) {
+	masks, err := NewMaskSet(2, 5)
+	if err != nil {
+		t.Fatalf("NewMaskSet(2, 5) returned an error: %v", err)
+	}
+	for i := Mask(0); i < 8; i++ {
+		want := i == 2 || i == 5
+		if got := masks.contains(i); got != want {
+			t.Errorf("contains(%d) = %v, want %v", i, got, want)
+		}
+	}
+
+	excluded, err := ExcludeMasks(2, 5)
+	if err != nil {
+		t.Fatalf("ExcludeMasks(2, 5) returned an error: %v", err)
+	}
+	for i := Mask(0); i < 8; i++ {
+		want := i != 2 && i != 5
+		if got := excluded.contains(i); got != want {
+			t.Errorf("contains(%d) = %v, want %v", i, got, want)
+		}
+	}
+}
+
+// TestAllMasksContainsEverything checks that the zero value, AllMasks,
+// restricts nothing, matching EncodeSegmentsAdvanced's unrestricted
+// automatic selection (msk == nil).
+func TestAllMasksContainsEverything(t *testing.T) {
+	for i := Mask(0); i < 8; i++ {
+		if !AllMasks.contains(i) {
+			t.Errorf("AllMasks.contains(%d) = false, want true", i)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"fmt"
+
+	"github.com/nayuki/qrcodegen/mask"
+	"github.com/nayuki/qrcodegen/qrsegment"
+)
+
+// PaddingOptions controls the non-standard aspects of how a symbol's data
+// codewords are assembled, for generating spec-conformance and
+// scanner-robustness test suites rather than for everyday encoding.
+//
+// The zero value reproduces the standard behavior used by EncodeSegments:
+// the terminator is emitted, and padding alternates between 0xEC and 0x11.
+type PaddingOptions struct {
+	// PadBytes is the cycle of bytes appended after the terminator to fill
+	// the rest of the symbol's data capacity. If empty, it defaults to the
+	// standard {0xEC, 0x11} alternation.
+	PadBytes []byte
+
+	// DisableEccBoost, if true, never raises the error correction level
+	// above the one requested, even when doing so would not require a
+	// larger version.
+	DisableEccBoost bool
+
+	// OmitTerminator, if true, skips the terminator bits that normally
+	// separate segment data from padding. This produces a symbol that does
+	// not conform to the QR Code spec.
+	OmitTerminator bool
+}
+
+// EncodeSegmentsAdvancedWithPadding behaves like EncodeSegmentsAdvanced, but
+// lets the caller override the terminator and padding behavior via opts
+// instead of always using the spec-mandated 0xEC/0x11 padding pattern and
+// terminator.
+//
+// A nil opts is equivalent to the zero value of PaddingOptions, which
+// reproduces the standard, spec-conformant behavior.
+//
+// Returns an *ErrVersionRange error if minVersion > maxVersion, or an error
+// if msk is non-nil and *msk is outside [0, 7].
+func EncodeSegmentsAdvancedWithPadding(segs []*qrsegment.QrSegment, ecl QrCodeEcc, minVersion, maxVersion Version, msk *Mask, boostEcl bool, opts *PaddingOptions) (*QrCode, error) {
+	if minVersion > maxVersion {
+		return nil, &ErrVersionRange{MinVersion: minVersion, MaxVersion: maxVersion}
+	}
+	if msk != nil {
+		if _, err := mask.NewChecked(msk.Value()); err != nil {
+			return nil, fmt.Errorf("qrcodegen: invalid mask: %w", err)
+		}
+	}
+	if opts == nil {
+		opts = &PaddingOptions{}
+	}
+	if opts.DisableEccBoost {
+		boostEcl = false
+	}
+
+	// Find the minimal version number to use
+	version := minVersion
+	var dataUsedBits int
+	for {
+		dataCapacityBits := getNumDataCodewords(version, ecl) * 8
+		dataUsed, ok := qrsegment.GetTotalBits(segs, version)
+		if ok && dataUsed <= dataCapacityBits {
+			dataUsedBits = dataUsed
+			break
+		}
+		if version >= maxVersion { // All versions in the range could not fit the given data
+			return nil, newErrDataTooLong(segs, ok, dataUsed, dataCapacityBits, version, ecl)
+		}
+		version++
+	}
+
+	// Increase the error correction level while the data still fits in the current version number
+	for _, newEcl := range []QrCodeEcc{Medium, Quartile, High} { // From low to high
+		if boostEcl && dataUsedBits <= getNumDataCodewords(version, newEcl)*8 {
+			ecl = newEcl
+		}
+	}
+
+	dataCodewords, _ := assembleDataCodewordsWithPadding(segs, version, ecl, opts)
+	qr, _ := encodeCodewords(version, ecl, dataCodewords, msk, AllMasks, nil)
+	return qr, nil
+}
+
+// assembleDataCodewordsWithPadding behaves like assembleDataCodewords, but
+// applies opts's terminator and padding overrides instead of the spec's
+// fixed terminator and 0xEC/0x11 alternation.
+func assembleDataCodewordsWithPadding(segs []*qrsegment.QrSegment, version Version, ecl QrCodeEcc, opts *PaddingOptions) ([]byte, int) {
+	var bb BitBuffer
+	for _, seg := range segs {
+		bb.AppendBits(seg.Mode().ModeBits(), 4)
+		bb.AppendBits(uint32(seg.NumChars()), seg.Mode().NumCharCountBits(version))
+		for _, bit := range seg.Data() {
+			bb.AppendBits(b2u32(bit), 1)
+		}
+	}
+	segmentBits := bb.Len()
+
+	// Add terminator and pad up to a byte if applicable
+	dataCapacityBits := getNumDataCodewords(version, ecl) * 8
+	if !opts.OmitTerminator {
+		numZeroBits := min(4, dataCapacityBits-bb.Len())
+		bb.AppendBits(0, numZeroBits)
+	}
+	numZeroBits := (8 - bb.Len()%8) % 8
+	bb.AppendBits(0, numZeroBits)
+
+	// Pad with the requested byte cycle until data capacity is reached
+	padBytes := opts.PadBytes
+	if len(padBytes) == 0 {
+		padBytes = []byte{0xEC, 0x11}
+	}
+	for i := 0; bb.Len() < dataCapacityBits; i++ {
+		bb.AppendBits(uint32(padBytes[i%len(padBytes)]), 8)
+	}
+
+	// Pack bits into bytes in big endian
+	dataCodewords := make([]byte, bb.Len()/8)
+	for i, bit := range bb.bits {
+		if bit {
+			dataCodewords[i>>3] |= 1 << uint(7-(i&7))
+		}
+	}
+
+	return dataCodewords, bb.Len() - segmentBits
+}
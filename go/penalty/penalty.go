@@ -0,0 +1,246 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Package penalty analyzes the mask-penalty rules (ISO/IEC 18004 section
+// 7.8.3, rules N1 through N4) against an arbitrary square module grid,
+// reporting every offending run, block, and pattern along with its
+// coordinates and score contribution.
+//
+// Unlike the scoring built into the core qrcodegen package, which only
+// needs a single aggregate score to pick the best mask, this package is
+// meant for scanner-compatibility investigations and education: it accepts
+// any [][]bool grid (not just one produced by this library, e.g. a
+// hand-edited or decoded symbol) and explains where each penalty point
+// came from.
+package penalty
+
+import "fmt"
+
+// Rule identifies which of the four penalty rules a Finding belongs to.
+type Rule int
+
+const (
+	N1 Rule = iota + 1 // Adjacent same-colored modules in a row or column
+	N2                 // 2x2 blocks of same-colored modules
+	N3                 // Finder-like 1:1:3:1:1 patterns in a row or column
+	N4                 // Imbalance between dark and light modules
+)
+
+func (r Rule) String() string {
+	switch r {
+	case N1:
+		return "N1"
+	case N2:
+		return "N2"
+	case N3:
+		return "N3"
+	case N4:
+		return "N4"
+	default:
+		return fmt.Sprintf("Rule(%d)", int(r))
+	}
+}
+
+// Finding describes one offending run, block, or pattern found by Analyze,
+// and the penalty points it contributes.
+type Finding struct {
+	Rule Rule
+
+	// Score is this finding's contribution to the grid's total penalty.
+	Score int32
+
+	// X, Y, Width, and Height bound the offending region: a run of
+	// same-colored modules (Width x 1 or 1 x Height), a 2x2 block, a
+	// finder-like pattern's 1:1:3:1:1 core, or (for N4, which has
+	// no single location) the entire grid.
+	X, Y, Width, Height int32
+
+	// Description is a short human-readable explanation, e.g. "run of 7
+	// dark modules" or "42% dark modules".
+	Description string
+}
+
+// Report is the result of analyzing one grid.
+type Report struct {
+	Findings   []Finding
+	TotalScore int32
+}
+
+const (
+	penaltyN1 = 3
+	penaltyN2 = 3
+	penaltyN3 = 40
+	penaltyN4 = 10
+)
+
+// Analyze scores grid, a square slice of rows (grid[y][x], true meaning
+// dark) against the N1-N4 rules and returns every offending region found.
+//
+// Panics if grid is not square (len(grid) == 0 or any row's length does not
+// equal len(grid)).
+func Analyze(grid [][]bool) Report {
+	size := int32(len(grid))
+	if size == 0 {
+		panic("grid must not be empty")
+	}
+	for _, row := range grid {
+		if int32(len(row)) != size {
+			panic("grid must be square")
+		}
+	}
+
+	var report Report
+	add := func(f Finding) {
+		report.Findings = append(report.Findings, f)
+		report.TotalScore += f.Score
+	}
+
+	// N1 and N3: runs and finder-like patterns, by row then by column.
+	for y := int32(0); y < size; y++ {
+		analyzeLine(size, func(i int32) bool { return grid[y][i] },
+			func(x, runLen int32, score int32, desc string) { add(Finding{N1, score, x, y, runLen, 1, desc}) },
+			func(x, runLen int32) { add(Finding{N3, penaltyN3, x, y, runLen, 1, "finder-like pattern"}) })
+	}
+	for x := int32(0); x < size; x++ {
+		analyzeLine(size, func(i int32) bool { return grid[i][x] },
+			func(y, runLen int32, score int32, desc string) { add(Finding{N1, score, x, y, 1, runLen, desc}) },
+			func(y, runLen int32) { add(Finding{N3, penaltyN3, x, y, 1, runLen, "finder-like pattern"}) })
+	}
+
+	// N2: 2x2 blocks of the same color.
+	for y := int32(0); y < size-1; y++ {
+		for x := int32(0); x < size-1; x++ {
+			c := grid[y][x]
+			if c == grid[y][x+1] && c == grid[y+1][x] && c == grid[y+1][x+1] {
+				add(Finding{N2, penaltyN2, x, y, 2, 2, "2x2 block of same-colored modules"})
+			}
+		}
+	}
+
+	// N4: overall dark/light imbalance.
+	var dark int32
+	for _, row := range grid {
+		for _, m := range row {
+			if m {
+				dark++
+			}
+		}
+	}
+	total := size * size
+	percent := dark * 100 / total
+	k := (abs32(dark*20-total*10)+total-1)/total - 1
+	if k > 0 {
+		add(Finding{N4, k * penaltyN4, 0, 0, size, size,
+			fmt.Sprintf("%d%% dark modules, too far from 50%%", percent)})
+	}
+
+	return report
+}
+
+// analyzeLine walks one row or column of length size (accessed via at),
+// calling onRun for every maximal same-colored run of 5 or more (reporting
+// its start index along the line and its N1 score), and onPattern once per
+// finder-like 1:1:3:1:1 pattern match (reporting the index of the first
+// module of the pattern's distinctive 7-unit core, and the core's width).
+func analyzeLine(size int32, at func(i int32) bool, onRun func(start, runLen, score int32, desc string), onPattern func(corePos, coreWidth int32)) {
+	runColor := false
+	runStart := int32(0)
+	runLen := int32(0)
+	fp := newFinderPenalty(size)
+	// coreEnd is the index just past the core, i.e. where the light run
+	// that was just added to the history begins.
+	check := func(coreEnd int32) {
+		if n := fp.countPatterns(); n > 0 {
+			width := 7 * fp.runHistory[1]
+			for j := int32(0); j < n; j++ {
+				onPattern(coreEnd-width, width)
+			}
+		}
+	}
+	for i := int32(0); i < size; i++ {
+		if at(i) == runColor {
+			runLen++
+		} else {
+			reportRun(runColor, runStart, runLen, onRun)
+			fp.addHistory(runLen)
+			if !runColor {
+				check(runStart)
+			}
+			runColor = at(i)
+			runStart = i
+			runLen = 1
+		}
+	}
+	reportRun(runColor, runStart, runLen, onRun)
+	// Terminate the line as the real scorer does: a black run still open
+	// is closed, then a final white border run is always added.
+	terminatedLen := runLen
+	coreEnd := runStart
+	if runColor {
+		fp.addHistory(terminatedLen)
+		terminatedLen = 0
+		coreEnd = size
+	}
+	fp.addHistory(terminatedLen + size)
+	check(coreEnd)
+}
+
+func reportRun(color bool, start, runLen int32, onRun func(start, runLen, score int32, desc string)) {
+	if runLen < 5 {
+		return
+	}
+	score := penaltyN1 + (runLen - 5)
+	word := "dark"
+	if !color {
+		word = "light"
+	}
+	onRun(start, runLen, score, fmt.Sprintf("run of %d %s modules", runLen, word))
+}
+
+// finderPenalty mirrors the core package's run-history tracking used to
+// detect the 1:1:3:1:1 finder-like pattern, but additionally exposes
+// whether (and where) a pattern matched so that Analyze can report it.
+type finderPenalty struct {
+	qrSize     int32
+	runHistory [7]int32
+}
+
+func newFinderPenalty(size int32) *finderPenalty {
+	return &finderPenalty{qrSize: size}
+}
+
+func (f *finderPenalty) addHistory(currentRunLength int32) {
+	if f.runHistory[0] == 0 {
+		currentRunLength += f.qrSize
+	}
+	copy(f.runHistory[1:], f.runHistory[:len(f.runHistory)-1])
+	f.runHistory[0] = currentRunLength
+}
+
+// countPatterns can only be called immediately after a white run is added,
+// and returns how many of the two finder-like pattern shapes (light border
+// on the left, on the right, or both) match the current run history.
+func (f *finderPenalty) countPatterns() int32 {
+	rh := f.runHistory
+	n := rh[1]
+	core := n > 0 && rh[2] == n && rh[3] == n*3 && rh[4] == n && rh[5] == n
+	var result int32
+	if core && rh[0] >= n*4 && rh[6] >= n {
+		result++
+	}
+	if core && rh[6] >= n*4 && rh[0] >= n {
+		result++
+	}
+	return result
+}
+
+func abs32(x int32) int32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
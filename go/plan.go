@@ -0,0 +1,73 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import "github.com/nayuki/qrcodegen/qrsegment"
+
+// PlanResult reports the outcome of a dry-run version/ECC search, without
+// building the symbol's grid or running mask selection.
+type PlanResult struct {
+	// Version is the version that EncodeSegmentsAdvanced would choose.
+	Version Version
+	// ErrorCorrectionLevel is the ECC level that would be used, which may
+	// be higher than the requested level if boostEcl allowed it.
+	ErrorCorrectionLevel QrCodeEcc
+	// BitsUsed is the number of bits the segments occupy at Version.
+	BitsUsed int
+	// CapacityBits is the data capacity, in bits, of Version at
+	// ErrorCorrectionLevel.
+	CapacityBits int
+}
+
+// HeadroomBits returns the number of unused data bits at the planned
+// version and ECC level.
+func (p PlanResult) HeadroomBits() int {
+	return p.CapacityBits - p.BitsUsed
+}
+
+// Plan computes the version, final ECC level, and bit usage that
+// EncodeSegmentsAdvanced would produce for the given segments, without
+// allocating the module grid or running mask selection.
+//
+// This lets form validators give instant as-you-type feedback on whether a
+// payload fits, at a fraction of the cost of a full encode.
+//
+// Returns an *ErrVersionRange error if minVersion > maxVersion.
+func Plan(segs []*qrsegment.QrSegment, ecl QrCodeEcc, minVersion, maxVersion Version, boostEcl bool) (PlanResult, error) {
+	if minVersion > maxVersion {
+		return PlanResult{}, &ErrVersionRange{MinVersion: minVersion, MaxVersion: maxVersion}
+	}
+
+	version := minVersion
+	var dataUsedBits int
+	for {
+		dataCapacityBits := getNumDataCodewords(version, ecl) * 8
+		dataUsed, ok := qrsegment.GetTotalBits(segs, version)
+		if ok && dataUsed <= dataCapacityBits {
+			dataUsedBits = dataUsed
+			break
+		}
+		if version >= maxVersion {
+			return PlanResult{}, newErrDataTooLong(segs, ok, dataUsed, dataCapacityBits, version, ecl)
+		}
+		version++
+	}
+
+	for _, newEcl := range []QrCodeEcc{Medium, Quartile, High} {
+		if boostEcl && dataUsedBits <= getNumDataCodewords(version, newEcl)*8 {
+			ecl = newEcl
+		}
+	}
+
+	return PlanResult{
+		Version:              version,
+		ErrorCorrectionLevel: ecl,
+		BitsUsed:             dataUsedBits,
+		CapacityBits:         getNumDataCodewords(version, ecl) * 8,
+	}, nil
+}
@@ -0,0 +1,44 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+// UnmaskedModule returns the color that module (x, y) held immediately
+// after the codewords were drawn, before the mask pattern that this symbol
+// settled on was applied. Function modules (finder patterns, timing
+// patterns, etc.) are never masked, so for those positions this returns the
+// same value as GetModule.
+//
+// This is a teaching and debugging aid for inspecting how a mask pattern
+// changes a symbol; the masked result returned by GetModule is what a
+// scanner actually reads.
+//
+// Panics if x or y is out of bounds.
+func (q *QrCode) UnmaskedModule(x, y int32) bool {
+	if x < 0 || x >= q.size || y < 0 || y >= q.size {
+		panic("module coordinates out of bounds")
+	}
+	bit := q.GetModule(x, y)
+	if !q.IsFunctionModule(x, y) && q.mask.Func()(x, y) {
+		bit = !bit
+	}
+	return bit
+}
+
+// UnmaskedGrid returns every module's pre-mask color (see UnmaskedModule)
+// as a size-by-size slice of rows, grid[y][x].
+func (q *QrCode) UnmaskedGrid() [][]bool {
+	grid := make([][]bool, q.size)
+	for y := int32(0); y < q.size; y++ {
+		row := make([]bool, q.size)
+		for x := int32(0); x < q.size; x++ {
+			row[x] = q.UnmaskedModule(x, y)
+		}
+		grid[y] = row
+	}
+	return grid
+}
@@ -0,0 +1,138 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Package qrcache provides an LRU cache keyed by a QR Code's payload and
+// encoding parameters, for services that see heavy repetition in the
+// data they're asked to encode (e.g. the same URL requested over and
+// over) and don't want to re-run the encoder, or a downstream renderer,
+// on every request.
+package qrcache
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// Key identifies one encode request: a payload plus every parameter that
+// can change its result. Mask is -1 for automatic mask selection, or a
+// forced mask value in [0, 7].
+type Key struct {
+	Payload              string
+	ErrorCorrectionLevel qrcodegen.QrCodeEcc
+	MinVersion           qrcodegen.Version
+	MaxVersion           qrcodegen.Version
+	BoostEcl             bool
+	Mask                 int8
+}
+
+// KeyFor builds a Key from the arguments EncodeSegmentsAdvanced would
+// take, treating data as the raw payload bytes (regardless of whether
+// the caller ultimately encodes it as text or binary).
+func KeyFor(data []byte, ecl qrcodegen.QrCodeEcc, minVersion, maxVersion qrcodegen.Version, msk *qrcodegen.Mask, boostEcl bool) Key {
+	m := int8(-1)
+	if msk != nil {
+		m = int8(*msk)
+	}
+	return Key{
+		Payload:              string(data),
+		ErrorCorrectionLevel: ecl,
+		MinVersion:           minVersion,
+		MaxVersion:           maxVersion,
+		BoostEcl:             boostEcl,
+		Mask:                 m,
+	}
+}
+
+// Cache is a fixed-capacity, least-recently-used cache from Key to a
+// value of type V, typically either *qrcodegen.QrCode or the bytes of a
+// rendered image. It is safe for concurrent use.
+//
+// Every call to GetOrCompute for the same Key, concurrent or not, can
+// return the same V value, not a copy of it: a *qrcodegen.QrCode is safe to
+// share this way since it is immutable, but if V is a []byte or other
+// reference type, every caller that received it is holding an alias to the
+// one cached copy, and must treat it as read-only. A caller that needs a
+// private copy to mutate must make one itself.
+type Cache[V any] struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List // of *entry[V], most recently used at the front
+	items    map[Key]*list.Element
+}
+
+type entry[V any] struct {
+	key   Key
+	value V
+}
+
+// New returns an empty Cache holding at most capacity entries.
+//
+// Panics if capacity is not positive.
+func New[V any](capacity int) *Cache[V] {
+	if capacity <= 0 {
+		panic("capacity must be positive")
+	}
+	return &Cache[V]{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[Key]*list.Element),
+	}
+}
+
+// GetOrCompute returns the cached value for key, computing and caching it
+// via compute if it isn't already present. If compute returns an error,
+// nothing is cached and the error is returned unchanged.
+func (c *Cache[V]) GetOrCompute(key Key, compute func() (V, error)) (V, error) {
+	c.mu.Lock()
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		value := el.Value.(*entry[V]).value
+		c.mu.Unlock()
+		return value, nil
+	}
+	c.mu.Unlock()
+
+	value, err := compute()
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		// Another caller computed and inserted key while we were
+		// computing our own copy; keep theirs to avoid surprising a
+		// concurrent reader with two different objects for one key.
+		c.ll.MoveToFront(el)
+		return el.Value.(*entry[V]).value, nil
+	}
+	el := c.ll.PushFront(&entry[V]{key: key, value: value})
+	c.items[key] = el
+	if c.ll.Len() > c.capacity {
+		c.removeOldest()
+	}
+	return value, nil
+}
+
+// Len returns the number of entries currently cached.
+func (c *Cache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+func (c *Cache[V]) removeOldest() {
+	el := c.ll.Back()
+	if el == nil {
+		return
+	}
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*entry[V]).key)
+}
@@ -0,0 +1,1477 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ * - The above copyright notice and this permission notice shall be included in
+ *   all copies or substantial portions of the Software.
+ * - The Software is provided "as is", without warranty of any kind, express or
+ *   implied, including but not limited to the warranties of merchantability,
+ *   fitness for a particular purpose and noninfringement. In no event shall the
+ *   authors or copyright holders be liable for any claim, damages or other
+ *   liability, whether in an action of contract, tort or otherwise, arising from,
+ *   out of or in connection with the Software or the use or other dealings in the
+ *   Software.
+ */
+
+// Package qrcodegen generates QR Codes from text strings and byte arrays.
+//
+// This project aims to be the best, clearest QR Code generator library.
+// The primary goals are flexible options and absolute correctness.
+// Secondary goals are compact implementation size and good documentation
+// comments.
+//
+// Home page with live JavaScript demo, extensive descriptions, and
+// competitor comparisons:
+// https://www.nayuki.io/page/qr-code-generator-library
+//
+// # Features
+//
+// Core features:
+//
+//   - Available in 6 programming languages (Java, TypeScript/JavaScript,
+//     Python, Rust, C++, C), with this Go port providing nearly equal
+//     functionality
+//   - Supports encoding all 40 versions (sizes) and all 4 error correction
+//     levels, as per the QR Code Model 2 standard
+//   - Output formats: raw modules/pixels, SVG XML string
+//   - Detects finder-like penalty patterns more accurately than other
+//     implementations
+//   - Encodes numeric and special-alphanumeric text in less space than
+//     general text
+//   - Open source code under the permissive MIT License
+//
+// Manual parameters:
+//
+//   - Caller can specify minimum and maximum version numbers allowed, then
+//     the library will automatically choose the smallest version in the
+//     range that fits the data
+//   - Caller can specify the mask pattern manually, otherwise the library
+//     will automatically evaluate all 8 masks and select the optimal one
+//   - Caller can specify an absolute error correction level, or allow the
+//     library to boost it if doing so doesn't increase the version number
+//   - Caller can create a list of data segments manually and add ECI
+//     segments
+package qrcodegen
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/nayuki/qrcodegen/bch"
+	"github.com/nayuki/qrcodegen/mask"
+	"github.com/nayuki/qrcodegen/qrsegment"
+	"github.com/nayuki/qrcodegen/rs"
+)
+
+// Version is a QR Code version number, between 1 and 40 (inclusive).
+type Version = qrsegment.Version
+
+// MinVersion and MaxVersion are the smallest and largest version numbers
+// supported by the QR Code Model 2 standard.
+const (
+	MinVersion = qrsegment.MinVersion
+	MaxVersion = qrsegment.MaxVersion
+)
+
+// Mask is a number between 0 and 7 (inclusive) identifying one of the 8
+// standard QR Code mask patterns.
+type Mask = mask.Mask
+
+// QrCode is a QR Code symbol, a type of two-dimensional barcode.
+//
+// Invented by Denso Wave and described in the ISO/IEC 18004 standard.
+//
+// Instances of this type represent an immutable square grid of black and
+// white cells. The package provides factory functions to create a QR Code
+// from text or binary data. It covers the QR Code Model 2 specification,
+// supporting all versions (sizes) from 1 to 40, all 4 error correction
+// levels, and 4 character encoding modes.
+//
+// A *QrCode has no exported mutator methods and exposes its module grid
+// only through GetModule and IsFunctionModule, which copy nothing because
+// they return a single bool; nothing about it can be used to reach the
+// underlying grid slices. Once a factory function returns one, it is safe
+// to read from any number of goroutines concurrently, including rendering
+// it with the render package, caching it in a qrcache.Cache, or passing it
+// to multiple encoders (e.g. ToSvgString and a render.To* function) at once.
+//
+// Ways to create a QrCode object:
+//
+//   - High level: Take the payload data and call EncodeText or EncodeBinary.
+//   - Mid level: Custom-make the list of segments and call EncodeSegments or
+//     EncodeSegmentsAdvanced.
+//   - Low level: Custom-make the array of data codeword bytes (including
+//     segment headers and final padding, excluding error correction
+//     codewords), supply the appropriate version number, and call
+//     EncodeCodewords.
+//
+// (Note that all ways require supplying the desired error correction
+// level.)
+type QrCode struct {
+	// Scalar parameters:
+	version              Version
+	size                 int32
+	errorCorrectionLevel QrCodeEcc
+	mask                 Mask
+
+	// Grids of modules/pixels, with dimensions of size*size:
+	modules    []bool // false = white, true = black. Immutable after the constructor finishes.
+	isFunction []bool // indicates function modules, which are not subject to masking. Retained after construction; see IsFunctionModule.
+}
+
+/*---- Static factory functions (high level) ----*/
+
+// EncodeText returns a QR Code representing the given Unicode text string at
+// the given error correction level.
+//
+// As a conservative upper bound, this function is guaranteed to succeed for
+// strings that have 738 or fewer Unicode code points if the low error
+// correction level is used. The smallest possible QR Code version is
+// automatically chosen for the output. The ECC level of the result may be
+// higher than the ecl argument if it can be done without increasing the
+// version; use EncodeTextWithOptions with DisableEccBoost to keep ecl
+// exactly as given.
+func EncodeText(text string, ecl QrCodeEcc) (*QrCode, error) {
+	return EncodeTextWithOptions(text, ecl, EncodeTextOptions{})
+}
+
+// Utf8Policy selects how EncodeTextWithOptions handles text that is not
+// valid UTF-8, e.g. a string that was assembled from a byte slice without
+// first validating it as text.
+type Utf8Policy int
+
+const (
+	// Utf8Replace segments text as usual. Go already decodes each invalid
+	// byte sequence encountered while ranging over a string as one
+	// U+FFFD REPLACEMENT CHARACTER, so the resulting QR Code silently
+	// encodes a replacement character in place of the invalid bytes. This
+	// is what EncodeText does, preserved for backward compatibility.
+	Utf8Replace Utf8Policy = iota
+
+	// Utf8Error rejects text that is not valid UTF-8 by returning an
+	// error, instead of silently substituting replacement characters.
+	Utf8Error
+
+	// Utf8RawBytes ignores the UTF-8 validity of text entirely and
+	// encodes its raw bytes as a single byte mode segment, the same as
+	// calling EncodeBinary([]byte(text), ecl). Use this when text actually
+	// holds arbitrary binary data rather than decoded Unicode text.
+	Utf8RawBytes
+)
+
+// StrictnessPolicy selects how thoroughly EncodeTextWithOptions validates
+// text before segmenting it.
+type StrictnessPolicy int
+
+const (
+	// Lenient accepts any text that some segment mode can represent,
+	// including ASCII control characters, and only reports a problem once
+	// the encode pipeline actually fails to fit it. This is what
+	// EncodeText and EncodeTextAdvanced use.
+	//
+	// Every strictness level, including Lenient, rejects text with no
+	// chance of fitting any QR Code (i.e. it would still be rejected at
+	// MaxVersion and the Low error correction level) immediately as an
+	// *ErrDataTooLong, without first building a bit buffer for it or
+	// running the full version search at the caller's requested ecl; see
+	// checkSegmentCapacity.
+	Lenient StrictnessPolicy = iota
+
+	// Strict additionally rejects text up front rather than deferring to
+	// whatever error the encode pipeline happens to produce: an ASCII
+	// control character other than tab, newline, or carriage return is
+	// reported as an *ErrControlCharacter naming its exact byte offset,
+	// instead of being silently encoded.
+	//
+	// This package's text inputs are already required to be valid UTF-8
+	// Go strings (see Utf8Policy for the exception), so there is no
+	// separate implicit-charset guess for Strict to disable.
+	//
+	// Regulated payloads (payment, healthcare records) typically want
+	// this fail-fast behavior, with enough detail in the error to
+	// pinpoint the exact bad byte in the upstream value.
+	Strict
+)
+
+// AlgorithmVersion pins which revision of this package's segmentation and
+// mask tie-breaking heuristics produced a symbol. This package's public API
+// is stable, but which of several equally spec-valid symbols it produces
+// for the same input is not guaranteed across releases unless pinned this
+// way — an organization that prints and archives a symbol may need to
+// regenerate the exact same bytes years later, even after a later release
+// improves those heuristics.
+type AlgorithmVersion int
+
+const (
+	// AlgorithmV1 is the algorithm this package implements today: mask
+	// selection breaks ties by taking the lowest-numbered mask among those
+	// with minimal penalty score (see QrCode.getPenaltyScore), and
+	// MakeSegments chooses one numeric, alphanumeric, or byte segment for
+	// the whole input, without optimizing mode switches. It is the zero
+	// value and what EncodeText uses.
+	//
+	// A future heuristic improvement (e.g. optimal multi-segment mode
+	// switching) will ship as a new constant rather than changing what
+	// AlgorithmV1 produces, so pinning AlgorithmV1 keeps producing
+	// identical output for as long as this package exists.
+	AlgorithmV1 AlgorithmVersion = iota
+)
+
+// EncodeTextOptions configures EncodeTextWithOptions. The zero value
+// reproduces EncodeText's behavior: Utf8Replace, Lenient, AlgorithmV1, and
+// ECC boosting enabled.
+type EncodeTextOptions struct {
+	Utf8       Utf8Policy
+	Strictness StrictnessPolicy
+
+	// Algorithm pins the segmentation and mask tie-breaking heuristics
+	// used, for archival reproducibility; see AlgorithmVersion. The zero
+	// value, AlgorithmV1, is the only version that exists today.
+	Algorithm AlgorithmVersion
+
+	// DisableEccBoost, if true, uses the requested error correction level
+	// exactly as given instead of opportunistically raising it (see
+	// EncodeText's doc comment) whenever the chosen version has spare
+	// capacity. Set this when the caller picked Low specifically for
+	// maximum capacity headroom, or wants output that doesn't change if a
+	// future release's boosting heuristic does.
+	//
+	// Has no effect when Strategy is MaxEclAtVersion, which always searches
+	// for the best ECC level regardless.
+	DisableEccBoost bool
+
+	// Strategy selects how the version and ECC level search trades size
+	// against robustness; see VersionStrategy. The zero value is
+	// SmallestVersion, matching EncodeText's behavior.
+	Strategy VersionStrategy
+}
+
+// EncodeTextAdvanced is like EncodeText, but lets the caller choose how
+// invalid UTF-8 in text is handled via policy; see Utf8Policy.
+func EncodeTextAdvanced(text string, ecl QrCodeEcc, policy Utf8Policy) (*QrCode, error) {
+	return EncodeTextWithOptions(text, ecl, EncodeTextOptions{Utf8: policy})
+}
+
+// EncodeTextWithOptions is like EncodeText, but lets the caller choose the
+// UTF-8, strictness, and algorithm-pinning policies applied to text before
+// it is segmented, whether ECC boosting is disabled, and the version/ECC
+// search strategy used; see Utf8Policy, StrictnessPolicy, AlgorithmVersion,
+// EncodeTextOptions.DisableEccBoost, and VersionStrategy.
+func EncodeTextWithOptions(text string, ecl QrCodeEcc, opts EncodeTextOptions) (*QrCode, error) {
+	switch opts.Algorithm {
+	case AlgorithmV1:
+		// The only version that exists; fall through to the rest of this function.
+	default:
+		return nil, fmt.Errorf("qrcodegen: unrecognized AlgorithmVersion %d", opts.Algorithm)
+	}
+
+	switch opts.Utf8 {
+	case Utf8Replace:
+		// Fall through to the validation and segmenting below.
+	case Utf8Error:
+		if !utf8.ValidString(text) {
+			return nil, fmt.Errorf("qrcodegen: text is not valid UTF-8")
+		}
+	case Utf8RawBytes:
+		return EncodeBinary([]byte(text), ecl)
+	default:
+		return nil, fmt.Errorf("qrcodegen: unrecognized Utf8Policy %d", opts.Utf8)
+	}
+
+	if opts.Strictness == Strict {
+		for pos, c := range text {
+			if c < 0x20 && c != '\t' && c != '\n' && c != '\r' {
+				return nil, &ErrControlCharacter{Pos: pos, Rune: c}
+			}
+		}
+	}
+
+	mode, dataBits := classifyForCapacityCheck(text)
+	if err := checkSegmentCapacity(mode, dataBits); err != nil {
+		return nil, err
+	}
+	return EncodeSegmentsWithStrategy(qrsegment.MakeSegments(text), ecl, opts.Strategy, !opts.DisableEccBoost)
+}
+
+// classifyForCapacityCheck reports the mode MakeSegments would choose for
+// text (by the same numeric/alphanumeric/byte rule) and the number of data
+// bits that mode would need, computed directly from len(text) rather than
+// by appending bits one character at a time.
+func classifyForCapacityCheck(text string) (qrsegment.QrSegmentMode, int) {
+	switch {
+	case qrsegment.IsNumeric(text):
+		n := len(text)
+		return qrsegment.Numeric, 10*(n/3) + [3]int{0, 4, 7}[n%3]
+	case qrsegment.IsAlphanumeric(text):
+		n := len(text)
+		return qrsegment.Alphanumeric, 11*(n/2) + (n%2)*6
+	default:
+		return qrsegment.Byte, len(text) * 8
+	}
+}
+
+// checkSegmentCapacity returns an *ErrDataTooLong if a single segment of
+// the given mode holding dataBits data bits could never fit any QR Code,
+// i.e. it would still exceed the capacity of version 40 (the largest
+// symbol) at the Low error correction level (the level leaving the most
+// room for data), the most permissive combination there is.
+//
+// mode and dataBits are cheap to compute from an input's length alone (see
+// classifyForCapacityCheck and EncodeBinary), so calling this before
+// MakeSegments/MakeBytes lets EncodeText and EncodeBinary reject a wildly
+// oversized input immediately, instead of first building a bit buffer
+// proportional to its length only to have the version/ECC search fail
+// anyway. Because Low at version 40 is already the most capacity any
+// combination offers, failing here means no other version or ECC level
+// could have fit it either, so the returned error's HasSuggestion is
+// always false.
+func checkSegmentCapacity(mode qrsegment.QrSegmentMode, dataBits int) error {
+	used := 4 + mode.NumCharCountBits(MaxVersion) + dataBits
+	capacityBits := DataCapacityBits(MaxVersion, Low)
+	if used <= capacityBits {
+		return nil
+	}
+	return &ErrDataTooLong{
+		RequiredBits:         used,
+		CapacityBits:         capacityBits,
+		MaxVersionTried:      MaxVersion,
+		ErrorCorrectionLevel: Low,
+	}
+}
+
+// EncodeBinary returns a QR Code representing the given binary data at the
+// given error correction level.
+//
+// This function always encodes using the binary segment mode, not any text
+// mode. The maximum number of bytes allowed is 2953. The smallest possible
+// QR Code version is automatically chosen for the output. The ECC level of
+// the result may be higher than the ecl argument if it can be done without
+// increasing the version.
+func EncodeBinary(data []byte, ecl QrCodeEcc) (*QrCode, error) {
+	if err := checkSegmentCapacity(qrsegment.Byte, len(data)*8); err != nil {
+		return nil, err
+	}
+	seg := qrsegment.MakeBytes(data)
+	return EncodeSegments([]*qrsegment.QrSegment{seg}, ecl)
+}
+
+// MaxBinaryBytes is the largest number of bytes EncodeBinary can ever
+// accept, i.e. the byte mode data capacity of version 40 at the low error
+// correction level: (DataCapacityBits(MaxVersion, Low) - 4 - 16) / 8, after
+// the 4-bit mode indicator and 16-bit character count.
+const MaxBinaryBytes = 2953
+
+// EncodeBinaryReader reads all of r and returns a QR Code representing it
+// at the given error correction level, like EncodeBinary.
+//
+// limit caps the number of bytes read from r; a non-positive limit is
+// treated as MaxBinaryBytes, the hard ceiling EncodeBinary itself enforces.
+// EncodeBinaryReader reads at most limit+1 bytes, so it can report an
+// oversize input as soon as that extra byte is seen, without buffering an
+// unbounded or malicious reader in full first.
+func EncodeBinaryReader(r io.Reader, limit int, ecl QrCodeEcc) (*QrCode, error) {
+	if limit <= 0 || limit > MaxBinaryBytes {
+		limit = MaxBinaryBytes
+	}
+	buf := make([]byte, limit+1)
+	n, err := io.ReadFull(r, buf)
+	switch err {
+	case nil:
+		return nil, fmt.Errorf("qrcodegen: reader has more than %d bytes", limit)
+	case io.ErrUnexpectedEOF, io.EOF:
+		return EncodeBinary(buf[:n], ecl)
+	default:
+		return nil, err
+	}
+}
+
+/*---- Static factory functions (mid level) ----*/
+
+// EncodeSegments returns a QR Code representing the given segments at the
+// given error correction level.
+//
+// The smallest possible QR Code version is automatically chosen for the
+// output. The ECC level of the result may be higher than the ecl argument if
+// it can be done without increasing the version.
+//
+// This function allows the caller to create a custom sequence of segments
+// that switches between modes (such as alphanumeric and byte) to encode
+// text in less space. This is a mid-level API; the high-level API is
+// EncodeText and EncodeBinary.
+func EncodeSegments(segs []*qrsegment.QrSegment, ecl QrCodeEcc) (*QrCode, error) {
+	return EncodeSegmentsAdvanced(segs, ecl, MinVersion, MaxVersion, nil, true)
+}
+
+// versionStrategyKind discriminates the cases VersionStrategy can hold.
+type versionStrategyKind int
+
+const (
+	// versionStrategySmallest finds the smallest version that fits the
+	// segments at the requested ecl, then boosts ecl as far as that
+	// version's spare capacity allows; this is what EncodeSegments does.
+	versionStrategySmallest versionStrategyKind = iota
+	// versionStrategyMinEcl is like versionStrategySmallest, but treats
+	// the strategy's own ecl as a floor beneath which the version search
+	// never drops to save space.
+	versionStrategyMinEcl
+	// versionStrategyMaxEclAtVersion fixes the version and searches for
+	// the highest error correction level that fits the segments within
+	// it, for a caller with a hard size budget who wants the most
+	// robustness that budget allows.
+	versionStrategyMaxEclAtVersion
+)
+
+// VersionStrategy selects how EncodeSegmentsWithStrategy (and, through its
+// Strategy option, EncodeTextWithOptions) trades symbol size against error
+// correction, instead of always taking the smallest version that fits a
+// single requested ecl. Different products weigh this differently: a
+// sticker that must print at a fixed size wants the most robustness that
+// size allows, while a dense inventory label wants the smallest symbol at
+// a guaranteed minimum robustness.
+//
+// The zero value is SmallestVersion, the strategy EncodeSegments and
+// EncodeText use.
+type VersionStrategy struct {
+	kind versionStrategyKind
+	ecl  QrCodeEcc
+	ver  Version
+}
+
+// SmallestVersion is the default strategy: the smallest version that fits
+// the segments at the requested ecl, then boosted as far as that version's
+// spare capacity allows.
+func SmallestVersion() VersionStrategy {
+	return VersionStrategy{kind: versionStrategySmallest}
+}
+
+// SmallestVersionAtLeastEcl is like SmallestVersion, but never settles for
+// a smaller version by using less error correction than minEcl: the version
+// search requires at least minEcl to fit, though boosting can still raise
+// the result above minEcl if the requested ecl is already higher or spare
+// capacity allows it.
+func SmallestVersionAtLeastEcl(minEcl QrCodeEcc) VersionStrategy {
+	return VersionStrategy{kind: versionStrategyMinEcl, ecl: minEcl}
+}
+
+// MaxEclAtVersion fixes the output at the given version and searches for
+// the highest error correction level (High down to Low) that fits the
+// segments within it, instead of searching for the smallest version. The
+// requested ecl is ignored; use this when the symbol's size is fixed by
+// something else (a label template, a fixed print size) and maximum
+// robustness within that budget matters more than the requested level.
+func MaxEclAtVersion(ver Version) VersionStrategy {
+	return VersionStrategy{kind: versionStrategyMaxEclAtVersion, ver: ver}
+}
+
+// EncodeSegmentsWithStrategy is like EncodeSegments, but lets the caller
+// pick how the version and ECC level search trades size against
+// robustness via strategy; see VersionStrategy. boostEcl is the same knob
+// EncodeSegmentsAdvanced takes; it is ignored when strategy is
+// MaxEclAtVersion, which always searches for the best level regardless.
+//
+// Returns an *ErrDataTooLong if strategy is MaxEclAtVersion(ver) and the
+// segments don't fit at ver even at the Low error correction level.
+func EncodeSegmentsWithStrategy(segs []*qrsegment.QrSegment, ecl QrCodeEcc, strategy VersionStrategy, boostEcl bool) (*QrCode, error) {
+	switch strategy.kind {
+	case versionStrategySmallest:
+		return EncodeSegmentsAdvanced(segs, ecl, MinVersion, MaxVersion, nil, boostEcl)
+	case versionStrategyMinEcl:
+		if ecl < strategy.ecl {
+			ecl = strategy.ecl
+		}
+		return EncodeSegmentsAdvanced(segs, ecl, MinVersion, MaxVersion, nil, boostEcl)
+	case versionStrategyMaxEclAtVersion:
+		ver := strategy.ver
+		dataUsedBits, fits := qrsegment.GetTotalBits(segs, ver)
+		best := Low
+		found := false
+		for _, candidate := range []QrCodeEcc{Low, Medium, Quartile, High} {
+			if fits && dataUsedBits <= getNumDataCodewords(ver, candidate)*8 {
+				best = candidate
+				found = true
+			}
+		}
+		if !found {
+			return nil, newErrDataTooLong(segs, fits, dataUsedBits, getNumDataCodewords(ver, Low)*8, ver, Low)
+		}
+		return EncodeSegmentsAdvanced(segs, best, ver, ver, nil, false)
+	default:
+		return nil, fmt.Errorf("qrcodegen: unrecognized VersionStrategy")
+	}
+}
+
+// EncodeSegmentsAdvanced returns a QR Code representing the given segments
+// with the given encoding parameters.
+//
+// The smallest possible QR Code version within the given range is
+// automatically chosen for the output. If boostEcl is true, then the ECC
+// level of the result may be higher than the ecl argument if it can be done
+// without increasing the version. The mask argument is either a pointer to
+// a value in [0, 7] to force that mask, or nil to automatically choose an
+// appropriate mask (which may be slow).
+//
+// This function allows the caller to create a custom sequence of segments
+// that switches between modes (such as alphanumeric and byte) to encode
+// text in less space. This is a mid-level API; the high-level API is
+// EncodeText and EncodeBinary.
+//
+// Returns an *ErrVersionRange error if minVersion > maxVersion, or an error
+// if msk is non-nil and *msk is outside [0, 7].
+func EncodeSegmentsAdvanced(segs []*qrsegment.QrSegment, ecl QrCodeEcc, minVersion, maxVersion Version, msk *Mask, boostEcl bool) (*QrCode, error) {
+	return EncodeSegmentsAdvancedContext(context.Background(), segs, ecl, minVersion, maxVersion, msk, boostEcl, nil)
+}
+
+// EncodeSegmentsAdvancedContext behaves like EncodeSegmentsAdvanced, but
+// additionally reports each pipeline stage's duration to observer (if
+// non-nil): "version_search", "ecc_boost", "assemble_codewords", and
+// "mask_selection", the last of which dominates when msk is nil and the
+// library must evaluate all 8 masks. ctx is passed through to observer
+// unused otherwise.
+//
+// Returns an *ErrVersionRange error if minVersion > maxVersion, or an error
+// if msk is non-nil and *msk was not produced by mask.New/mask.NewChecked
+// (e.g. it was constructed directly from an out-of-range int32).
+func EncodeSegmentsAdvancedContext(ctx context.Context, segs []*qrsegment.QrSegment, ecl QrCodeEcc, minVersion, maxVersion Version, msk *Mask, boostEcl bool, observer Observer) (*QrCode, error) {
+	return encodeSegmentsAdvanced(ctx, segs, ecl, minVersion, maxVersion, msk, AllMasks, boostEcl, observer)
+}
+
+// encodeSegmentsAdvanced is the shared implementation behind
+// EncodeSegmentsAdvancedContext and EncodeSegmentsAdvancedMaskSetContext.
+// msk forces a single mask (masks is ignored when msk is non-nil); msk ==
+// nil searches automatically, restricted to masks.
+func encodeSegmentsAdvanced(ctx context.Context, segs []*qrsegment.QrSegment, ecl QrCodeEcc, minVersion, maxVersion Version, msk *Mask, masks MaskSet, boostEcl bool, observer Observer) (*QrCode, error) {
+	if minVersion > maxVersion {
+		return nil, &ErrVersionRange{MinVersion: minVersion, MaxVersion: maxVersion}
+	}
+	if msk != nil {
+		if _, err := mask.NewChecked(msk.Value()); err != nil {
+			return nil, fmt.Errorf("qrcodegen: invalid mask: %w", err)
+		}
+	}
+
+	// Find the minimal version number to use
+	version := minVersion
+	var dataUsedBits int
+	err := observeStage(ctx, observer, "version_search", func() error {
+		for {
+			dataCapacityBits := getNumDataCodewords(version, ecl) * 8
+			dataUsed, ok := qrsegment.GetTotalBits(segs, version)
+			if ok && dataUsed <= dataCapacityBits {
+				dataUsedBits = dataUsed
+				return nil
+			}
+			if version >= maxVersion { // All versions in the range could not fit the given data
+				return newErrDataTooLong(segs, ok, dataUsed, dataCapacityBits, version, ecl)
+			}
+			version++
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Increase the error correction level while the data still fits in the current version number
+	observeStage(ctx, observer, "ecc_boost", func() error {
+		for _, newEcl := range []QrCodeEcc{Medium, Quartile, High} { // From low to high
+			if boostEcl && dataUsedBits <= getNumDataCodewords(version, newEcl)*8 {
+				ecl = newEcl
+			}
+		}
+		return nil
+	})
+
+	var dataCodewords []byte
+	observeStage(ctx, observer, "assemble_codewords", func() error {
+		dataCodewords, _ = assembleDataCodewords(segs, version, ecl)
+		return nil
+	})
+
+	var qr *QrCode
+	observeStage(ctx, observer, "mask_selection", func() error {
+		qr, _ = encodeCodewords(version, ecl, dataCodewords, msk, masks, nil)
+		return nil
+	})
+	return qr, nil
+}
+
+// assembleDataCodewords concatenates segs into a data bit string, adds the
+// terminator and padding, and packs the result into codeword bytes for a QR
+// Code of the given version and error correction level. The caller must
+// have already verified that the segments fit. The second return value is
+// the number of padding bits appended after the terminator (i.e. the total
+// length minus the raw segment bits), for diagnostic reporting.
+func assembleDataCodewords(segs []*qrsegment.QrSegment, version Version, ecl QrCodeEcc) ([]byte, int) {
+	var bb BitBuffer
+	for _, seg := range segs {
+		bb.AppendBits(seg.Mode().ModeBits(), 4)
+		bb.AppendBits(uint32(seg.NumChars()), seg.Mode().NumCharCountBits(version))
+		for _, bit := range seg.Data() {
+			bb.AppendBits(b2u32(bit), 1)
+		}
+	}
+	segmentBits := bb.Len()
+
+	// Add terminator and pad up to a byte if applicable
+	dataCapacityBits := getNumDataCodewords(version, ecl) * 8
+	numZeroBits := min(4, dataCapacityBits-bb.Len())
+	bb.AppendBits(0, numZeroBits)
+	numZeroBits = (8 - bb.Len()%8) % 8
+	bb.AppendBits(0, numZeroBits)
+
+	// Pad with alternating bytes until data capacity is reached
+	for padByte := uint32(0xEC); bb.Len() < dataCapacityBits; padByte ^= 0xEC ^ 0x11 {
+		bb.AppendBits(padByte, 8)
+	}
+
+	// Pack bits into bytes in big endian
+	dataCodewords := make([]byte, bb.Len()/8)
+	for i, bit := range bb.bits {
+		if bit {
+			dataCodewords[i>>3] |= 1 << uint(7-(i&7))
+		}
+	}
+
+	return dataCodewords, bb.Len() - segmentBits
+}
+
+/*---- Constructor (low level) ----*/
+
+// EncodeCodewords creates a new QR Code with the given version number, error
+// correction level, data codeword bytes, and mask number.
+//
+// This is a low-level API that most callers should not use directly. A
+// mid-level API is EncodeSegments.
+func EncodeCodewords(ver Version, ecl QrCodeEcc, dataCodewords []byte, msk *Mask) *QrCode {
+	qr, _ := encodeCodewords(ver, ecl, dataCodewords, msk, AllMasks, nil)
+	return qr
+}
+
+// encodeCodewords is the shared implementation behind EncodeCodewords. msk
+// forces a single mask; with msk == nil, automatic selection considers
+// only the masks masks allows (AllMasks considers all 8). When
+// maskPenaltiesOut is non-nil, it is filled with the penalty score of every
+// one of the 8 standard masks regardless of msk or masks, for reporting via
+// Diagnostics.
+func encodeCodewords(ver Version, ecl QrCodeEcc, dataCodewords []byte, msk *Mask, masks MaskSet, maskPenaltiesOut *[8]int32) (*QrCode, Mask) {
+	size := int32(ver)*4 + 17
+	result := &QrCode{
+		version:              ver,
+		size:                 size,
+		errorCorrectionLevel: ecl,
+		mask:                 mask.New(0), // Dummy value
+		modules:              make([]bool, size*size),
+		isFunction:           make([]bool, size*size),
+	}
+
+	result.drawFunctionPatterns()
+	allCodewords := result.addEccAndInterleave(dataCodewords)
+	result.drawCodewords(allCodewords)
+
+	if msk == nil || maskPenaltiesOut != nil { // Need to evaluate some or all of the 8 masks
+		minPenalty := int32(1<<31 - 1)
+		var best Mask
+		for i := int32(0); i < 8; i++ {
+			newMask := mask.New(i)
+			allowed := masks.contains(newMask)
+			if !allowed && maskPenaltiesOut == nil {
+				// Not a candidate, and nobody needs its exact score.
+				continue
+			}
+			result.applyMask(newMask)
+			result.drawFormatBits(newMask)
+			// maskPenaltiesOut needs every mask's exact score, so only
+			// prune when nobody's asking for that; pruning never changes
+			// which mask is best, since getPenaltyScoreUpTo only returns
+			// early once a candidate can no longer beat minPenalty.
+			var penalty int32
+			var exact bool
+			if maskPenaltiesOut != nil {
+				penalty, exact = result.getPenaltyScore(), true
+				maskPenaltiesOut[i] = penalty
+			} else {
+				penalty, exact = result.getPenaltyScoreUpTo(minPenalty)
+			}
+			if allowed && exact && penalty < minPenalty {
+				best = newMask
+				minPenalty = penalty
+			}
+			result.applyMask(newMask) // Undoes the mask due to XOR
+		}
+		if msk == nil { // Automatically choose the best mask
+			msk = &best
+		}
+	}
+	result.mask = *msk
+	result.applyMask(*msk)      // Apply the final choice of mask
+	result.drawFormatBits(*msk) // Overwrite old format bits
+
+	return result, *msk
+}
+
+// EncodeCodewordsExperimentalMask creates a new QR Code like EncodeCodewords,
+// but applies a caller-supplied masking predicate instead of one of the 8
+// standard mask patterns.
+//
+// This is strictly an experimentation aid for researching alternative
+// masking heuristics. The QR Code format can only record which of the 8
+// standard masks was used, so a symbol produced this way writes a
+// placeholder mask value into its format bits and is NOT guaranteed to be
+// decodable by standard-conforming QR Code readers. Do not use this for
+// symbols that need to be scanned by third-party software.
+func EncodeCodewordsExperimentalMask(ver Version, ecl QrCodeEcc, dataCodewords []byte, maskFunc mask.Predicate) *QrCode {
+	size := int32(ver)*4 + 17
+	result := &QrCode{
+		version:              ver,
+		size:                 size,
+		errorCorrectionLevel: ecl,
+		mask:                 mask.New(0), // Placeholder; does not reflect maskFunc
+		modules:              make([]bool, size*size),
+		isFunction:           make([]bool, size*size),
+	}
+
+	result.drawFunctionPatterns()
+	allCodewords := result.addEccAndInterleave(dataCodewords)
+	result.drawCodewords(allCodewords)
+	result.applyMaskFunc(maskFunc)
+	result.drawFormatBits(mask.New(0)) // Placeholder mask value; see doc comment above
+
+	return result
+}
+
+// EncodeCodewordsAllMasks creates all 8 standard-mask variants of a QR Code
+// with the given version number, error correction level, and data codeword
+// bytes, returning them alongside each variant's penalty score, both indexed
+// by mask value.
+//
+// This is a low-level API that most callers should not use directly. It
+// exists for demos and teaching material that want to show every mask
+// side by side: it builds the unmasked grid and interleaved codewords only
+// once and reuses them for all 8 variants, instead of calling
+// EncodeCodewords 8 times and redoing that work each time.
+func EncodeCodewordsAllMasks(ver Version, ecl QrCodeEcc, dataCodewords []byte) ([8]*QrCode, [8]int32) {
+	size := int32(ver)*4 + 17
+	base := &QrCode{
+		version:              ver,
+		size:                 size,
+		errorCorrectionLevel: ecl,
+		mask:                 mask.New(0), // Dummy value
+		modules:              make([]bool, size*size),
+		isFunction:           make([]bool, size*size),
+	}
+	base.drawFunctionPatterns()
+	allCodewords := base.addEccAndInterleave(dataCodewords)
+	base.drawCodewords(allCodewords)
+
+	var results [8]*QrCode
+	var penalties [8]int32
+	for i := int32(0); i < 8; i++ {
+		msk := mask.New(i)
+		qr := &QrCode{
+			version:              ver,
+			size:                 size,
+			errorCorrectionLevel: ecl,
+			mask:                 msk,
+			modules:              append([]bool(nil), base.modules...),
+			isFunction:           base.isFunction,
+		}
+		qr.applyMask(msk)
+		qr.drawFormatBits(msk)
+		penalties[i] = qr.getPenaltyScore()
+		results[i] = qr
+	}
+	return results, penalties
+}
+
+// EncodeCodewordsFastMask behaves like EncodeCodewords with msk == nil
+// (automatic mask selection), but stops evaluating further candidate masks
+// as soon as one scores at or below goodEnoughPenalty, instead of always
+// trying all 8. Every mask it does evaluate is still scored exactly, or
+// pruned via lower-bound reasoning that can't change which mask wins (see
+// getPenaltyScoreUpTo); goodEnoughPenalty is the only source of
+// non-strictness.
+//
+// Passing a goodEnoughPenalty below any achievable score (e.g. a negative
+// number) makes this equivalent to EncodeCodewords(ver, ecl, dataCodewords,
+// nil): every mask gets evaluated and the true best one is chosen. Passing
+// a realistic threshold trades optimality for speed: for large versions,
+// where scoring even one mask is expensive, accepting the first
+// good-enough mask in standard order (0 through 7) can skip most of the
+// remaining evaluations.
+func EncodeCodewordsFastMask(ver Version, ecl QrCodeEcc, dataCodewords []byte, goodEnoughPenalty int32) (*QrCode, Mask) {
+	size := int32(ver)*4 + 17
+	result := &QrCode{
+		version:              ver,
+		size:                 size,
+		errorCorrectionLevel: ecl,
+		mask:                 mask.New(0), // Dummy value
+		modules:              make([]bool, size*size),
+		isFunction:           make([]bool, size*size),
+	}
+	result.drawFunctionPatterns()
+	allCodewords := result.addEccAndInterleave(dataCodewords)
+	result.drawCodewords(allCodewords)
+
+	minPenalty := int32(1<<31 - 1)
+	var best Mask
+	for i := int32(0); i < 8; i++ {
+		newMask := mask.New(i)
+		result.applyMask(newMask)
+		result.drawFormatBits(newMask)
+		penalty, exact := result.getPenaltyScoreUpTo(minPenalty)
+		result.applyMask(newMask) // Undoes the mask due to XOR
+		if exact && penalty < minPenalty {
+			best = newMask
+			minPenalty = penalty
+			if penalty <= goodEnoughPenalty {
+				break
+			}
+		}
+	}
+	result.mask = best
+	result.applyMask(best)
+	result.drawFormatBits(best)
+	return result, best
+}
+
+/*---- Public methods ----*/
+
+// Version returns this QR Code's version, in the range [1, 40].
+func (q *QrCode) Version() Version {
+	return q.version
+}
+
+// Size returns this QR Code's size, in the range [21, 177].
+//
+// This always equals int32(q.Version())*4 + 17, per the spec's formula for
+// deriving a symbol's module count from its version number.
+func (q *QrCode) Size() int32 {
+	return q.size
+}
+
+// ErrorCorrectionLevel returns this QR Code's error correction level.
+func (q *QrCode) ErrorCorrectionLevel() QrCodeEcc {
+	return q.errorCorrectionLevel
+}
+
+// GetMask returns this QR Code's mask, in the range [0, 7].
+//
+// Even if a QR Code was created with automatic masking requested (mask =
+// nil), the resulting object still has a mask value between 0 and 7.
+func (q *QrCode) GetMask() Mask {
+	return q.mask
+}
+
+// GetModule returns the color of the module (pixel) at the given
+// coordinates, which is false for white or true for black.
+//
+// The top left corner has the coordinates (x=0, y=0). If the given
+// coordinates are out of bounds, then false (white) is returned.
+func (q *QrCode) GetModule(x, y int32) bool {
+	return 0 <= x && x < q.size && 0 <= y && y < q.size && q.module(x, y)
+}
+
+// module returns the color of the module at the given coordinates, which
+// must be in bounds.
+func (q *QrCode) module(x, y int32) bool {
+	return q.modules[y*q.size+x]
+}
+
+// IsFunctionModule reports whether the module at the given coordinates is a
+// function module (part of a finder, separator, timing, alignment, format,
+// or version pattern) rather than a data/ECC module.
+//
+// Stylized renderers use this to draw finder-pattern "eyes" and other
+// function modules differently from ordinary data modules. If the given
+// coordinates are out of bounds, false is returned.
+func (q *QrCode) IsFunctionModule(x, y int32) bool {
+	return 0 <= x && x < q.size && 0 <= y && y < q.size && q.isFunction[y*q.size+x]
+}
+
+func (q *QrCode) setModule(x, y int32, isBlack bool) {
+	q.modules[y*q.size+x] = isBlack
+}
+
+// ToSvgString returns a string of SVG code for an image depicting this QR
+// Code, with the given number of border modules.
+//
+// The string always uses Unix newlines (\n), regardless of the platform.
+//
+// Panics if border is negative; use ToSvgStringChecked if border isn't
+// already known to be non-negative.
+func (q *QrCode) ToSvgString(border int32) string {
+	return q.ToSvgStringWithOptions(border, SvgOptions{})
+}
+
+// ToSvgStringChecked is like ToSvgString, but returns an error instead of
+// panicking if border is negative.
+func (q *QrCode) ToSvgStringChecked(border int32) (string, error) {
+	return q.ToSvgStringWithOptionsChecked(border, SvgOptions{})
+}
+
+// SvgOptions controls the styling hooks available to ToSvgStringWithOptions,
+// beyond the plain black-on-white symbol that ToSvgString produces.
+type SvgOptions struct {
+	// CssClass, if non-empty, is added as a "class" attribute on the dark
+	// module path, letting a page stylesheet control its fill color
+	// instead of the hardcoded "#000000".
+	CssClass string
+
+	// Id, if non-empty, is added as an "id" attribute on the root <svg>
+	// element.
+	Id string
+
+	// Fragment, if true, omits the XML declaration and DOCTYPE, producing
+	// an SVG fragment suitable for inlining directly into an HTML document
+	// or template rather than saving as a standalone .svg file.
+	Fragment bool
+
+	// FinderColor, if non-empty, draws the three finder eyes separately
+	// from the rest of the dark modules, filled with this color instead
+	// of the data fill (CssClass, or "#000000"). Leave empty to draw the
+	// eyes as ordinary data modules.
+	FinderColor string
+
+	// FinderShape selects the eyes' outline when FinderColor is non-empty.
+	// Ignored otherwise.
+	FinderShape FinderShape
+}
+
+// ToSvgStringWithOptions behaves like ToSvgString, but applies the given
+// styling options.
+//
+// Panics if border is negative; use ToSvgStringWithOptionsChecked if
+// border isn't already known to be non-negative.
+func (q *QrCode) ToSvgStringWithOptions(border int32, opts SvgOptions) string {
+	s, err := q.ToSvgStringWithOptionsChecked(border, opts)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToSvgStringWithOptionsChecked is like ToSvgStringWithOptions, but
+// returns an error instead of panicking if border is negative.
+func (q *QrCode) ToSvgStringWithOptionsChecked(border int32, opts SvgOptions) (string, error) {
+	if border < 0 {
+		return "", fmt.Errorf("qrcodegen: border must be non-negative, got %d", border)
+	}
+	var result string
+	if !opts.Fragment {
+		result += "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n"
+		result += "<!DOCTYPE svg PUBLIC \"-//W3C//DTD SVG 1.1//EN\" \"http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd\">\n"
+	}
+	dimension := q.size + border*2
+	result += "<svg xmlns=\"http://www.w3.org/2000/svg\" version=\"1.1\""
+	if opts.Id != "" {
+		result += fmt.Sprintf(" id=\"%s\"", opts.Id)
+	}
+	result += fmt.Sprintf(" viewBox=\"0 0 %d %d\" stroke=\"none\">\n", dimension, dimension)
+	result += "\t<rect width=\"100%\" height=\"100%\" fill=\"#FFFFFF\"/>\n"
+	result += "\t<path d=\""
+	if opts.FinderColor != "" {
+		result += q.svgPathDataExcludingFinders(border)
+	} else {
+		result += q.svgPathData(border)
+	}
+	result += "\""
+	if opts.CssClass != "" {
+		result += fmt.Sprintf(" class=\"%s\"", opts.CssClass)
+	} else {
+		result += " fill=\"#000000\""
+	}
+	result += "/>\n"
+	if opts.FinderColor != "" {
+		result += "\t" + q.finderEyesSvg(border, opts.FinderShape, opts.FinderColor) + "\n"
+	}
+	result += "</svg>\n"
+	return result, nil
+}
+
+// svgPathData returns the "d" attribute value for a single SVG path that
+// draws every dark module, offset by border. Horizontally adjacent dark
+// modules in the same row are merged into one run-length rectangle instead
+// of one subpath per module, which keeps the path short for symbols with
+// long solid runs (e.g. finder patterns, timing patterns).
+func (q *QrCode) svgPathData(border int32) string {
+	var result string
+	first := true
+	for y := int32(0); y < q.size; y++ {
+		for x := int32(0); x < q.size; {
+			if !q.GetModule(x, y) {
+				x++
+				continue
+			}
+			runStart := x
+			for x < q.size && q.GetModule(x, y) {
+				x++
+			}
+			runLen := x - runStart
+			if !first {
+				result += " "
+			}
+			first = false
+			result += fmt.Sprintf("M%d,%dh%dv1h-%dz", runStart+border, y+border, runLen, runLen)
+		}
+	}
+	return result
+}
+
+/*---- Private helper methods for constructor: Drawing function modules ----*/
+
+func (q *QrCode) drawFunctionPatterns() {
+	size := q.size
+	for i := int32(0); i < size; i++ {
+		q.setFunctionModule(6, i, i%2 == 0)
+		q.setFunctionModule(i, 6, i%2 == 0)
+	}
+
+	// Draw 3 finder patterns (all corners except bottom right; overwrites some timing modules)
+	q.drawFinderPattern(3, 3)
+	q.drawFinderPattern(size-4, 3)
+	q.drawFinderPattern(3, size-4)
+
+	// Draw numerous alignment patterns
+	alignPatPos := q.getAlignmentPatternPositions()
+	numAlign := len(alignPatPos)
+	for i := 0; i < numAlign; i++ {
+		for j := 0; j < numAlign; j++ {
+			// Don't draw on the three finder corners
+			if !(i == 0 && j == 0 || i == 0 && j == numAlign-1 || i == numAlign-1 && j == 0) {
+				q.drawAlignmentPattern(alignPatPos[i], alignPatPos[j])
+			}
+		}
+	}
+
+	// Draw configuration data
+	q.drawFormatBits(mask.New(0)) // Dummy mask value; overwritten later in the constructor
+	q.drawVersion()
+}
+
+func (q *QrCode) drawFormatBits(msk Mask) {
+	data := q.errorCorrectionLevel.formatBits()<<3 | uint32(msk.Value())
+	bits := bch.FormatEncode(data)
+
+	// Draw first copy
+	for i := int32(0); i < 6; i++ {
+		q.setFunctionModule(8, i, getBit(bits, int(i)))
+	}
+	q.setFunctionModule(8, 7, getBit(bits, 6))
+	q.setFunctionModule(8, 8, getBit(bits, 7))
+	q.setFunctionModule(7, 8, getBit(bits, 8))
+	for i := int32(9); i < 15; i++ {
+		q.setFunctionModule(14-i, 8, getBit(bits, int(i)))
+	}
+
+	// Draw second copy
+	size := q.size
+	for i := int32(0); i < 8; i++ {
+		q.setFunctionModule(size-1-i, 8, getBit(bits, int(i)))
+	}
+	for i := int32(8); i < 15; i++ {
+		q.setFunctionModule(8, size-15+i, getBit(bits, int(i)))
+	}
+	q.setFunctionModule(8, size-8, true) // The "dark module": always black, regardless of version, ECC level, or mask.
+}
+
+func (q *QrCode) drawVersion() {
+	if q.version < 7 {
+		return
+	}
+	data := uint32(q.version) // uint6, in the range [7, 40]
+	bits := bch.VersionEncode(data)
+
+	for i := int32(0); i < 18; i++ {
+		bit := getBit(bits, int(i))
+		a := q.size - 11 + i%3
+		b := i / 3
+		q.setFunctionModule(a, b, bit)
+		q.setFunctionModule(b, a, bit)
+	}
+}
+
+func (q *QrCode) drawFinderPattern(x, y int32) {
+	for dy := int32(-4); dy <= 4; dy++ {
+		for dx := int32(-4); dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if 0 <= xx && xx < q.size && 0 <= yy && yy < q.size {
+				dist := max32(abs32(dx), abs32(dy)) // Chebyshev/infinity norm
+				q.setFunctionModule(xx, yy, dist != 2 && dist != 4)
+			}
+		}
+	}
+}
+
+func (q *QrCode) drawAlignmentPattern(x, y int32) {
+	for dy := int32(-2); dy <= 2; dy++ {
+		for dx := int32(-2); dx <= 2; dx++ {
+			q.setFunctionModule(x+dx, y+dy, max32(abs32(dx), abs32(dy)) != 1)
+		}
+	}
+}
+
+func (q *QrCode) setFunctionModule(x, y int32, isBlack bool) {
+	q.setModule(x, y, isBlack)
+	q.isFunction[y*q.size+x] = true
+}
+
+/*---- Private helper methods for constructor: Codewords and masking ----*/
+
+// addEccAndInterleave computes each Reed-Solomon block's ECC codewords and
+// interleaves all blocks' data and ECC codewords together into one byte
+// slice of length rawCodewords. The interleaving only ever reorders bytes
+// (each of data's bytes, plus each newly computed ECC byte, appears exactly
+// once in the result); CorrectedDataCodewords's block splitting undoes
+// exactly that reordering before error correction.
+func (q *QrCode) addEccAndInterleave(data []byte) []byte {
+	ver := q.version
+	ecl := q.errorCorrectionLevel
+	if len(data) != getNumDataCodewords(ver, ecl) {
+		panic("illegal argument")
+	}
+
+	numBlocks := tableGet(&numErrorCorrectionBlocks, int(ver), ecl)
+	blockEccLen := tableGet(&eccCodewordsPerBlock, int(ver), ecl)
+	rawCodewords := getNumRawDataModules(ver) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	blocks := make([][]byte, numBlocks)
+	rsDiv := rs.ComputeDivisor(blockEccLen)
+	k := 0
+	for i := 0; i < numBlocks; i++ {
+		datLen := shortBlockLen - blockEccLen
+		if i >= numShortBlocks {
+			datLen++
+		}
+		dat := append([]byte{}, data[k:k+datLen]...)
+		k += datLen
+		ecc := rs.ComputeRemainder(dat, rsDiv)
+		if i < numShortBlocks {
+			dat = append(dat, 0)
+		}
+		dat = append(dat, ecc...)
+		blocks[i] = dat
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := 0; i <= shortBlockLen; i++ {
+		for j, block := range blocks {
+			// Skip the padding byte in short blocks
+			if i != shortBlockLen-blockEccLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+func (q *QrCode) drawCodewords(data []byte) {
+	if len(data) != getNumRawDataModules(q.version)/8 {
+		panic("illegal argument")
+	}
+
+	i := 0 // Bit index into the data
+	// Do the funny zigzag scan
+	for right := q.size - 1; right >= 1; right -= 2 { // Index of right column in each column pair
+		if right == 6 {
+			right = 5
+		}
+		for vert := int32(0); vert < q.size; vert++ { // Vertical counter
+			for j := int32(0); j < 2; j++ {
+				x := right - j // Actual x coordinate
+				upward := (right+1)&2 == 0
+				var y int32
+				if upward {
+					y = q.size - 1 - vert
+				} else {
+					y = vert
+				}
+				if !q.isFunction[y*q.size+x] && i < len(data)*8 {
+					q.setModule(x, y, getBit(uint32(data[i>>3]), 7-(i&7)))
+					i++
+				}
+				// If this QR Code has any remainder bits (0 to 7), they were assigned as
+				// 0/false/white by the constructor and are left unchanged here
+			}
+		}
+	}
+}
+
+// applyMask XORs the codeword modules in this QR Code with the given mask
+// pattern. The function modules must be marked and the codeword bits must
+// be drawn before masking. Due to the arithmetic of XOR, calling applyMask
+// with the same mask value a second time will undo the mask. A final
+// well-formed QR Code needs exactly one (not zero, two, etc.) mask applied.
+func (q *QrCode) applyMask(msk Mask) {
+	q.applyMaskFunc(msk.Func())
+}
+
+// applyMaskFunc is the predicate-driven core of applyMask, factored out so
+// that EncodeCodewordsExperimentalMask can drive it with a non-standard
+// predicate.
+func (q *QrCode) applyMaskFunc(pred mask.Predicate) {
+	for y := int32(0); y < q.size; y++ {
+		for x := int32(0); x < q.size; x++ {
+			if pred(x, y) && !q.isFunction[y*q.size+x] {
+				q.setModule(x, y, !q.module(x, y))
+			}
+		}
+	}
+}
+
+func (q *QrCode) getPenaltyScore() int32 {
+	result, _ := q.getPenaltyScoreUpTo(1<<31 - 1)
+	return result
+}
+
+// getPenaltyScoreUpTo computes the same ISO/IEC 18004 N1-N4 penalty score
+// as getPenaltyScore, but may return early once the running total exceeds
+// limit: every rule only ever adds a non-negative amount, so once the
+// partial score exceeds limit, the final score can only be larger still.
+// This lets a caller comparing candidate masks against a known
+// best-score-so-far (passed as limit) skip the rest of the scoring for any
+// mask that's already lost, without changing which mask ends up chosen.
+//
+// The second return value reports whether scoring ran to completion; if
+// false, the first return value is only a lower bound on the true score,
+// not the score itself.
+func (q *QrCode) getPenaltyScoreUpTo(limit int32) (int32, bool) {
+	var result int32
+	size := q.size
+
+	// Adjacent modules in row having same color, and finder-like patterns
+	for y := int32(0); y < size; y++ {
+		runColor := false
+		runX := int32(0)
+		runHistory := newFinderPenalty(size)
+		for x := int32(0); x < size; x++ {
+			if q.module(x, y) == runColor {
+				runX++
+				if runX == 5 {
+					result += penaltyN1
+				} else if runX > 5 {
+					result++
+				}
+			} else {
+				runHistory.addHistory(runX)
+				if !runColor {
+					result += runHistory.countPatterns() * penaltyN3
+				}
+				runColor = q.module(x, y)
+				runX = 1
+			}
+		}
+		result += runHistory.terminateAndCount(runColor, runX) * penaltyN3
+		if result > limit {
+			return result, false
+		}
+	}
+	// Adjacent modules in column having same color, and finder-like patterns
+	for x := int32(0); x < size; x++ {
+		runColor := false
+		runY := int32(0)
+		runHistory := newFinderPenalty(size)
+		for y := int32(0); y < size; y++ {
+			if q.module(x, y) == runColor {
+				runY++
+				if runY == 5 {
+					result += penaltyN1
+				} else if runY > 5 {
+					result++
+				}
+			} else {
+				runHistory.addHistory(runY)
+				if !runColor {
+					result += runHistory.countPatterns() * penaltyN3
+				}
+				runColor = q.module(x, y)
+				runY = 1
+			}
+		}
+		result += runHistory.terminateAndCount(runColor, runY) * penaltyN3
+		if result > limit {
+			return result, false
+		}
+	}
+
+	// 2*2 blocks of modules having same color
+	for y := int32(0); y < size-1; y++ {
+		for x := int32(0); x < size-1; x++ {
+			color := q.module(x, y)
+			if color == q.module(x+1, y) && color == q.module(x, y+1) && color == q.module(x+1, y+1) {
+				result += penaltyN2
+			}
+		}
+	}
+	if result > limit {
+		return result, false
+	}
+
+	// Balance of black and white modules
+	var black int32
+	for _, b := range q.modules {
+		if b {
+			black++
+		}
+	}
+	total := size * size // Note that size is odd, so black/total != 1/2
+	// Compute the smallest integer k >= 0 such that (45-5k)% <= black/total <= (55+5k)%
+	k := (abs32(black*20-total*10)+total-1)/total - 1
+	result += k * penaltyN4
+	return result, true
+}
+
+/*---- Private helper functions ----*/
+
+func (q *QrCode) getAlignmentPatternPositions() []int32 {
+	ver := int32(q.version)
+	if ver == 1 {
+		return nil
+	}
+	numAlign := ver/7 + 2
+	var step int32
+	if ver == 32 {
+		step = 26
+	} else {
+		step = (ver*4 + numAlign*2 + 1) / (numAlign*2 - 2) * 2
+	}
+	result := make([]int32, 0, numAlign)
+	for i := numAlign - 2; i >= 0; i-- {
+		result = append(result, q.size-7-i*step)
+	}
+	result = append(result, 6)
+	// reverse
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result
+}
+
+// getNumRawDataModules returns the number of data bits that can be stored
+// in a QR Code of the given version number, after all function modules are
+// excluded. This includes remainder bits, so it might not be a multiple of
+// 8. The result is in the range [208, 29648].
+func getNumRawDataModules(ver Version) int {
+	v := int(ver)
+	result := (16*v+128)*v + 64
+	if v >= 2 {
+		numAlign := v/7 + 2
+		result -= (25*numAlign-10)*numAlign - 55
+		if v >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+// getNumDataCodewords returns the number of 8-bit data (i.e. not error
+// correction) codewords contained in any QR Code of the given version
+// number and error correction level, with remainder bits discarded.
+//
+// For a fixed ecl, this is non-decreasing in ver: a higher version number
+// never has less data capacity at the same error correction level.
+func getNumDataCodewords(ver Version, ecl QrCodeEcc) int {
+	return getNumRawDataModules(ver)/8 -
+		tableGet(&eccCodewordsPerBlock, int(ver), ecl)*tableGet(&numErrorCorrectionBlocks, int(ver), ecl)
+}
+
+// DataCapacityBits returns the number of bits available for segment data
+// (mode indicators, character counts, and payload combined) in a QR Code of
+// the given version and error correction level, i.e. getNumDataCodewords
+// times 8. This is the authoritative source for the per-version,
+// per-ECC-level capacity figures tabulated in ISO/IEC 18004 Annexes E and
+// D/7.8; callers that need a text character limit should derive it from
+// this function (see qrsegment.GetTotalBits) rather than hardcoding a
+// capacity number, which otherwise silently drifts as this table is
+// revised.
+func DataCapacityBits(ver Version, ecl QrCodeEcc) int {
+	return getNumDataCodewords(ver, ecl) * 8
+}
+
+/*---- Helper type for getPenaltyScore() ----*/
+
+type finderPenalty struct {
+	qrSize     int32
+	runHistory [7]int32
+}
+
+func newFinderPenalty(size int32) *finderPenalty {
+	return &finderPenalty{qrSize: size}
+}
+
+// addHistory pushes the given value to the front and drops the last value.
+func (f *finderPenalty) addHistory(currentRunLength int32) {
+	if f.runHistory[0] == 0 {
+		currentRunLength += f.qrSize // Add white border to initial run
+	}
+	copy(f.runHistory[1:], f.runHistory[:len(f.runHistory)-1])
+	f.runHistory[0] = currentRunLength
+}
+
+// countPatterns can only be called immediately after a white run is added,
+// and returns either 0, 1, or 2.
+func (f *finderPenalty) countPatterns() int32 {
+	rh := f.runHistory
+	n := rh[1]
+	core := n > 0 && rh[2] == n && rh[3] == n*3 && rh[4] == n && rh[5] == n
+	var result int32
+	if core && rh[0] >= n*4 && rh[6] >= n {
+		result++
+	}
+	if core && rh[6] >= n*4 && rh[0] >= n {
+		result++
+	}
+	return result
+}
+
+// terminateAndCount must be called at the end of a line (row or column) of
+// modules.
+func (f *finderPenalty) terminateAndCount(currentRunColor bool, currentRunLength int32) int32 {
+	if currentRunColor { // Terminate black run
+		f.addHistory(currentRunLength)
+		currentRunLength = 0
+	}
+	currentRunLength += f.qrSize // Add white border to final run
+	f.addHistory(currentRunLength)
+	return f.countPatterns()
+}
+
+/*---- Small numeric helpers ----*/
+
+func b2u32(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func abs32(x int32) int32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func max32(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,137 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/nayuki/qrcodegen"
+	"github.com/nayuki/qrcodegen/qrcache"
+	"github.com/nayuki/qrcodegen/render"
+)
+
+// TestConcurrentEncode checks that many goroutines can call EncodeText at
+// once (run with -race to catch any sharing of mutable state between
+// encodes) and each gets back the symbol its own input demands.
+func TestConcurrentEncode(t *testing.T) {
+	const n = 64
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			text := fmt.Sprintf("concurrent test payload %d", i)
+			qr, err := qrcodegen.EncodeText(text, qrcodegen.Medium)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if qr.ErrorCorrectionLevel() < qrcodegen.Medium {
+				errs <- fmt.Errorf("payload %d: got ecl %v, want at least qrcodegen.Medium", i, qr.ErrorCorrectionLevel())
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// TestConcurrentRenderSharedQrCode checks that one *qrcodegen.QrCode,
+// built once, can be read concurrently by many goroutines calling
+// ToSvgString and render.ToPNG on it at the same time, matching the
+// immutability guarantee documented on QrCode.
+func TestConcurrentRenderSharedQrCode(t *testing.T) {
+	qr, err := qrcodegen.EncodeText("https://www.nayuki.io/", qrcodegen.Quartile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 64
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			svg := qr.ToSvgString(4)
+			if svg == "" {
+				errs <- fmt.Errorf("ToSvgString returned empty string")
+			}
+			if _, err := render.ToPNG(qr, 4, 4, render.PNGOptions{}); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// TestConcurrentQrCache checks that qrcache.Cache's GetOrCompute is safe
+// under concurrent use: many goroutines racing to populate and read the
+// same small set of keys must all observe one shared *qrcodegen.QrCode per
+// key, with the compute function's EncodeText call happening without data
+// races.
+func TestConcurrentQrCache(t *testing.T) {
+	cache := qrcache.New[*qrcodegen.QrCode](4)
+	texts := []string{"alpha", "beta", "gamma", "delta"}
+
+	const n = 64
+	var wg sync.WaitGroup
+	results := make(chan *qrcodegen.QrCode, n*len(texts))
+	errs := make(chan error, n*len(texts))
+	for i := 0; i < n; i++ {
+		for _, text := range texts {
+			text := text
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				key := qrcache.KeyFor([]byte(text), qrcodegen.Medium, qrcodegen.MinVersion, qrcodegen.MaxVersion, nil, true)
+				qr, err := cache.GetOrCompute(key, func() (*qrcodegen.QrCode, error) {
+					return qrcodegen.EncodeText(text, qrcodegen.Medium)
+				})
+				if err != nil {
+					errs <- err
+					return
+				}
+				results <- qr
+			}()
+		}
+	}
+	wg.Wait()
+	close(results)
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	// Every goroutine racing on the same key must have observed the same
+	// *qrcodegen.QrCode instance, not independently computed copies.
+	seen := make(map[string]*qrcodegen.QrCode)
+	for qr := range results {
+		// qr.Size() distinguishes nothing on its own, so compare by
+		// pointer identity grouped by the rendered SVG, which is
+		// deterministic per input text.
+		svg := qr.ToSvgString(0)
+		if prev, ok := seen[svg]; ok {
+			if prev != qr {
+				t.Errorf("two goroutines for the same input text got different *qrcodegen.QrCode instances out of the cache")
+			}
+		} else {
+			seen[svg] = qr
+		}
+	}
+}
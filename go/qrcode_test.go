@@ -0,0 +1,170 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/nayuki/qrcodegen/rs"
+)
+
+// TestSizeFormula checks that Size always equals the version-to-module-count
+// formula from the QR Code spec (4*version + 17), for every legal version.
+func TestSizeFormula(t *testing.T) {
+	f := func(verOffset, eclOffset uint8) bool {
+		ver := MinVersion + Version(verOffset)%(MaxVersion-MinVersion+1)
+		ecl := QrCodeEcc(int(eclOffset) % 4)
+		data := make([]byte, getNumDataCodewords(ver, ecl))
+		qr := EncodeCodewords(ver, ecl, data, nil)
+		return qr.Size() == int32(ver)*4+17
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 200}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestDarkModuleAlwaysSet checks that the dark module at (8, size-8) is
+// black in every encoded symbol, regardless of version, ECC level, or mask,
+// as required by the spec (see drawFormatBits).
+func TestDarkModuleAlwaysSet(t *testing.T) {
+	f := func(verOffset uint8, eclOffset uint8, maskOffset uint8) bool {
+		ver := MinVersion + Version(verOffset)%(MaxVersion-MinVersion+1)
+		ecl := QrCodeEcc(int(eclOffset) % 4)
+		msk := Mask(int32(maskOffset) % 8)
+		data := make([]byte, getNumDataCodewords(ver, ecl))
+		qr, _ := encodeCodewords(ver, ecl, data, &msk, AllMasks, nil)
+		return qr.GetModule(8, qr.Size()-8)
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 200}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestCapacityMonotonic checks that getNumDataCodewords never decreases as
+// the version increases, for a fixed error correction level: a caller that
+// needs more data capacity should always be able to get it by moving to a
+// higher version.
+func TestCapacityMonotonic(t *testing.T) {
+	for _, ecl := range []QrCodeEcc{Low, Medium, Quartile, High} {
+		prev := getNumDataCodewords(MinVersion, ecl)
+		for ver := MinVersion + 1; ver <= MaxVersion; ver++ {
+			cur := getNumDataCodewords(ver, ecl)
+			if cur < prev {
+				t.Errorf("ecl=%v: capacity decreased from version %d (%d codewords) to version %d (%d codewords)", ecl, ver-1, prev, ver, cur)
+			}
+			prev = cur
+		}
+	}
+}
+
+// TestApplyMaskIsInvolution checks that applying the same mask pattern
+// twice in a row restores the original module grid, as documented on
+// applyMask.
+func TestApplyMaskIsInvolution(t *testing.T) {
+	f := func(verOffset uint8, maskOffset uint8, seed uint32) bool {
+		ver := MinVersion + Version(verOffset)%(MaxVersion-MinVersion+1)
+		msk := Mask(int32(maskOffset) % 8)
+		size := int32(ver)*4 + 17
+		qr := &QrCode{version: ver, size: size}
+		qr.modules = make([]bool, size*size)
+		qr.isFunction = make([]bool, size*size)
+		qr.drawFunctionPatterns()
+
+		// Fill the non-function modules with pseudo-random data so the
+		// check doesn't degenerate on an all-white grid.
+		state := seed | 1
+		for i := range qr.modules {
+			if !qr.isFunction[i] {
+				state = state*1664525 + 1013904223
+				qr.modules[i] = state&1 == 1
+			}
+		}
+		filled := append([]bool{}, qr.modules...)
+
+		qr.applyMask(msk)
+		qr.applyMask(msk)
+		for i := range qr.modules {
+			if qr.modules[i] != filled[i] {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 100}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestInterleaveIsPermutation checks that addEccAndInterleave's output is a
+// permutation of the bytes across all of its RS blocks (each block being
+// its share of data followed by its freshly computed ECC bytes): every
+// block byte appears in the result exactly once, as documented on
+// addEccAndInterleave.
+func TestInterleaveIsPermutation(t *testing.T) {
+	f := func(verOffset uint8, eclOffset uint8, seed uint32) bool {
+		ver := MinVersion + Version(verOffset)%(MaxVersion-MinVersion+1)
+		ecl := QrCodeEcc(int(eclOffset) % 4)
+		qr := &QrCode{version: ver, errorCorrectionLevel: ecl}
+
+		data := make([]byte, getNumDataCodewords(ver, ecl))
+		state := seed | 1
+		for i := range data {
+			state = state*1664525 + 1013904223
+			data[i] = byte(state >> 24)
+		}
+
+		result := qr.addEccAndInterleave(data)
+
+		// Recompute the same blocks addEccAndInterleave builds internally;
+		// the interleaved result must be exactly a reordering of their
+		// concatenation, with nothing added, dropped, or duplicated.
+		numBlocks := tableGet(&numErrorCorrectionBlocks, int(ver), ecl)
+		blockEccLen := tableGet(&eccCodewordsPerBlock, int(ver), ecl)
+		rawCodewords := getNumRawDataModules(ver) / 8
+		numShortBlocks := numBlocks - rawCodewords%numBlocks
+		shortBlockLen := rawCodewords / numBlocks
+
+		var want []byte
+		k := 0
+		for i := 0; i < numBlocks; i++ {
+			datLen := shortBlockLen - blockEccLen
+			if i >= numShortBlocks {
+				datLen++
+			}
+			dat := data[k : k+datLen]
+			k += datLen
+			ecc := rs.ComputeRemainder(dat, rs.ComputeDivisor(blockEccLen))
+			want = append(want, dat...)
+			want = append(want, ecc...)
+		}
+
+		if len(result) != len(want) || len(result) != rawCodewords {
+			return false
+		}
+		counts := make(map[byte]int)
+		for _, b := range want {
+			counts[b]++
+		}
+		for _, b := range result {
+			if counts[b] == 0 {
+				return false
+			}
+			counts[b]--
+		}
+		for _, c := range counts {
+			if c != 0 {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 100}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,195 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Package qrhttp provides an http.Handler that renders a QR Code symbol for
+// the text given in a query parameter, suitable for running as a public
+// "give me a QR code for this URL" microservice.
+package qrhttp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/nayuki/qrcodegen"
+	"github.com/nayuki/qrcodegen/render"
+)
+
+// DefaultMaxTextLength is the MaxTextLength used when Options leaves it at
+// zero: the byte mode capacity ceiling of the largest QR Code symbol
+// (version 40), beyond which EncodeText can never succeed anyway.
+const DefaultMaxTextLength = qrcodegen.MaxBinaryBytes
+
+// Options controls the behavior of the handler NewHandler returns.
+type Options struct {
+	// MaxTextLength caps the length (in bytes) of the "text" query
+	// parameter the handler will accept, rejecting longer requests with
+	// 413 Request Entity Too Large before attempting to encode them. Zero
+	// means DefaultMaxTextLength.
+	MaxTextLength int
+
+	// CacheMaxAge, if positive, is advertised via a
+	// "Cache-Control: public, max-age=..." response header. Zero omits
+	// the header, leaving caching to the caller's own reverse proxy
+	// configuration.
+	CacheMaxAge time.Duration
+
+	// HMACKey, if non-empty, requires every request to carry a "sig" query
+	// parameter equal to the hex-encoded HMAC-SHA256, keyed by HMACKey, of
+	// the request's other query parameters canonicalized by
+	// url.Values.Encode. This lets an operator hand out pre-signed links
+	// for specific parameter combinations without exposing an open
+	// redirect-style endpoint that renders arbitrary caller-supplied text.
+	HMACKey []byte
+}
+
+// NewHandler returns an http.Handler that serves a QR Code image for the
+// "text" query parameter on every request, in the format named by the
+// "format" parameter ("svg", the default, or "png"), at the error
+// correction level named by "ecl" ("L", "M" the default, "Q", or "H"), with
+// "scale" (PNG only, default 8) and "border" (default 4) controlling size.
+//
+// Responses carry a content-based ETag, so a conditional request bearing a
+// matching If-None-Match header gets a bodyless 304 Not Modified instead of
+// re-rendering and re-transferring an unchanged image.
+func NewHandler(opts Options) http.Handler {
+	maxTextLength := opts.MaxTextLength
+	if maxTextLength <= 0 {
+		maxTextLength = DefaultMaxTextLength
+	}
+	return &handler{opts: opts, maxTextLength: maxTextLength}
+}
+
+type handler struct {
+	opts          Options
+	maxTextLength int
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if len(h.opts.HMACKey) > 0 {
+		if !validSignature(query, h.opts.HMACKey) {
+			http.Error(w, "qrhttp: missing or invalid sig parameter", http.StatusForbidden)
+			return
+		}
+	}
+
+	text := query.Get("text")
+	if text == "" {
+		http.Error(w, "qrhttp: missing required \"text\" parameter", http.StatusBadRequest)
+		return
+	}
+	if len(text) > h.maxTextLength {
+		http.Error(w, fmt.Sprintf("qrhttp: \"text\" parameter exceeds %d bytes", h.maxTextLength), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	ecl, err := parseEcl(query.Get("ecl"))
+	if err != nil {
+		http.Error(w, "qrhttp: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	border, err := parseIntParam(query, "border", 4)
+	if err != nil || border < 0 {
+		http.Error(w, "qrhttp: invalid \"border\" parameter", http.StatusBadRequest)
+		return
+	}
+	scale, err := parseIntParam(query, "scale", 8)
+	if err != nil || scale <= 0 {
+		http.Error(w, "qrhttp: invalid \"scale\" parameter", http.StatusBadRequest)
+		return
+	}
+
+	qr, err := qrcodegen.EncodeText(text, ecl)
+	if err != nil {
+		http.Error(w, "qrhttp: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var body []byte
+	var contentType string
+	switch format := query.Get("format"); format {
+	case "", "svg":
+		body = []byte(qr.ToSvgString(int32(border)))
+		contentType = "image/svg+xml"
+	case "png":
+		body, err = render.ToPNG(qr, int32(scale), int32(border), render.PNGOptions{})
+		if err != nil {
+			http.Error(w, "qrhttp: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		contentType = "image/png"
+	default:
+		http.Error(w, fmt.Sprintf("qrhttp: unrecognized \"format\" parameter %q", format), http.StatusBadRequest)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+	w.Header().Set("ETag", etag)
+	if h.opts.CacheMaxAge > 0 {
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.opts.CacheMaxAge.Seconds())))
+	}
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Write(body)
+}
+
+// validSignature reports whether query carries a "sig" parameter equal to
+// the hex HMAC-SHA256, keyed by key, of every other parameter canonicalized
+// by url.Values.Encode (which sorts keys and percent-encodes values).
+func validSignature(query url.Values, key []byte) bool {
+	sig := query.Get("sig")
+	if sig == "" {
+		return false
+	}
+	given, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	unsigned := url.Values{}
+	for k, v := range query {
+		if k != "sig" {
+			unsigned[k] = v
+		}
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(unsigned.Encode()))
+	return hmac.Equal(given, mac.Sum(nil))
+}
+
+func parseEcl(s string) (qrcodegen.QrCodeEcc, error) {
+	switch s {
+	case "", "M":
+		return qrcodegen.Medium, nil
+	case "L":
+		return qrcodegen.Low, nil
+	case "Q":
+		return qrcodegen.Quartile, nil
+	case "H":
+		return qrcodegen.High, nil
+	default:
+		return 0, fmt.Errorf("unrecognized \"ecl\" parameter %q", s)
+	}
+}
+
+func parseIntParam(query url.Values, name string, def int) (int, error) {
+	s := query.Get(name)
+	if s == "" {
+		return def, nil
+	}
+	return strconv.Atoi(s)
+}
@@ -0,0 +1,45 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Package qrotel adapts qrcodegen.Observer to OpenTelemetry spans, kept
+// as a separate module so that depending on it (and transitively on
+// go.opentelemetry.io/otel) is opt-in and never forced on a caller of
+// the core qrcodegen module.
+package qrotel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// Observer adapts tracer into a qrcodegen.Observer, starting one span per
+// encode pipeline stage, named "qrcodegen.<stage>".
+func Observer(tracer trace.Tracer) qrcodegen.Observer {
+	return observer{tracer}
+}
+
+type observer struct {
+	tracer trace.Tracer
+}
+
+func (o observer) StageStart(ctx context.Context, stage string) func(error) {
+	_, span := o.tracer.Start(ctx, "qrcodegen."+stage, trace.WithAttributes(
+		attribute.String("qrcodegen.stage", stage),
+	))
+	return func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrsegment
+
+import "fmt"
+
+// BitReader reads bits one at a time from a byte slice, most significant
+// bit first, mirroring the order qrcodegen.BitBuffer.AppendBits writes them
+// in. DecodeSegments and the rest of this package's decoder use it to walk
+// a segment's data bits; it is exported so tests and other callers can
+// check a segment's bit layout against the spec directly, by reading
+// QrSegment.Data (packed with boolsToBytes-style packing) the same way the
+// decoder does.
+//
+// The zero value is not usable; construct one with NewBitReader.
+type BitReader struct {
+	data []byte
+	pos  int // Bit index into data
+}
+
+// NewBitReader returns a BitReader positioned at the start of data.
+func NewBitReader(data []byte) *BitReader {
+	return &BitReader{data: data}
+}
+
+// Pos returns the number of bits read so far.
+func (r *BitReader) Pos() int {
+	return r.pos
+}
+
+// Remaining returns the number of unread bits left in the underlying data.
+func (r *BitReader) Remaining() int {
+	return len(r.data)*8 - r.pos
+}
+
+// ReadBits reads the next n bits (0 <= n <= 32) and returns them as the low
+// n bits of the result, most significant bit first.
+//
+// Returns an error, leaving the reader's position unchanged, if n is
+// negative or there are fewer than n bits remaining.
+func (r *BitReader) ReadBits(n int) (uint32, error) {
+	if n < 0 || n > r.Remaining() {
+		return 0, fmt.Errorf("qrsegment: ran out of data while reading %d bits", n)
+	}
+	var result uint32
+	for i := 0; i < n; i++ {
+		bit := (r.data[r.pos/8] >> uint(7-r.pos%8)) & 1
+		result = result<<1 | uint32(bit)
+		r.pos++
+	}
+	return result, nil
+}
+
+// ReadBytes reads the next n whole bytes (8n bits) and returns them.
+//
+// Returns an error, leaving the reader's position unchanged, if n is
+// negative or there are fewer than 8n bits remaining.
+func (r *BitReader) ReadBytes(n int) ([]byte, error) {
+	if n < 0 || 8*n > r.Remaining() {
+		return nil, fmt.Errorf("qrsegment: ran out of data while reading %d bytes", n)
+	}
+	result := make([]byte, n)
+	for i := range result {
+		b, err := r.ReadBits(8)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = byte(b)
+	}
+	return result, nil
+}
+
+// readBools reads the next n bits as individual booleans, most significant
+// bit first, for reassembling a QrSegment's Data.
+func (r *BitReader) readBools(n int) ([]bool, error) {
+	result := make([]bool, n)
+	for i := range result {
+		bit, err := r.ReadBits(1)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = bit != 0
+	}
+	return result, nil
+}
@@ -0,0 +1,354 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrsegment
+
+import (
+	"fmt"
+	"strings"
+)
+
+// modeFromBits reverses QrSegmentMode.ModeBits.
+func modeFromBits(bits uint32) (QrSegmentMode, bool) {
+	switch bits {
+	case 0x1:
+		return Numeric, true
+	case 0x2:
+		return Alphanumeric, true
+	case 0x4:
+		return Byte, true
+	case 0x8:
+		return Kanji, true
+	case 0x7:
+		return Eci, true
+	case 0x3:
+		return StructuredAppend, true
+	default:
+		return 0, false
+	}
+}
+
+// DecodeSegments parses data (as produced by concatenating a QR Code's data
+// codewords, e.g. QrCode.DataCodewords) into the sequence of segments it was
+// assembled from, stopping at the first terminator (or when fewer than 4 bits
+// remain), since data is padded out to a whole number of codewords after the
+// real payload ends. Returns an error on an unrecognized mode indicator.
+//
+// This is the inverse of the segment concatenation performed inside
+// assembleDataCodewords; it does not interpret the data bits into text,
+// since that also requires tracking any ECI designators seen along the way.
+// Use SegmentsToText for that.
+func DecodeSegments(data []byte, ver Version) ([]*QrSegment, error) {
+	r := NewBitReader(data)
+	var result []*QrSegment
+	for r.Remaining() >= 4 {
+		modeBits, err := r.ReadBits(4)
+		if err != nil {
+			return nil, err
+		}
+		if modeBits == 0 {
+			break // Terminator, or the start of the zero-padding
+		}
+		mode, ok := modeFromBits(modeBits)
+		if !ok {
+			return nil, fmt.Errorf("qrsegment: unrecognized mode indicator 0x%X", modeBits)
+		}
+
+		var seg *QrSegment
+		switch mode {
+		case Eci:
+			seg, err = decodeEciSegment(r)
+		case StructuredAppend:
+			seg, err = decodeStructuredAppendSegment(r)
+		default:
+			seg, err = decodeCharacterSegment(r, mode, ver)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, seg)
+	}
+	return result, nil
+}
+
+// decodeCharacterSegment reads a Numeric, Alphanumeric, Byte, or Kanji
+// segment's character count field and exactly as many data bits as that
+// count requires for the mode, per the same bit-length formulas Validate
+// checks.
+func decodeCharacterSegment(r *BitReader, mode QrSegmentMode, ver Version) (*QrSegment, error) {
+	ccBits := mode.NumCharCountBits(ver)
+	numChars, err := r.ReadBits(ccBits)
+	if err != nil {
+		return nil, err
+	}
+	var dataLen int
+	switch mode {
+	case Numeric:
+		dataLen = (10*int(numChars) + 2) / 3
+	case Alphanumeric:
+		dataLen = (11*int(numChars) + 1) / 2
+	case Byte:
+		dataLen = 8 * int(numChars)
+	case Kanji:
+		dataLen = 13 * int(numChars)
+	default:
+		panic("unreachable")
+	}
+	bits, err := r.readBools(dataLen)
+	if err != nil {
+		return nil, fmt.Errorf("qrsegment: %v segment with numChars=%d: %w", mode, numChars, err)
+	}
+	return New(mode, int(numChars), bits), nil
+}
+
+// decodeEciSegment reads one of the three ECI designator encodings that
+// MakeEci produces (8, 16, or 24 bits total, distinguished by the first 1 to
+// 3 bits already having consumed the mode indicator).
+func decodeEciSegment(r *BitReader) (*QrSegment, error) {
+	first, err := r.ReadBits(1)
+	if err != nil {
+		return nil, err
+	}
+	var assignVal uint32
+	if first == 0 {
+		rest, err := r.ReadBits(7)
+		if err != nil {
+			return nil, err
+		}
+		assignVal = rest
+	} else {
+		second, err := r.ReadBits(1)
+		if err != nil {
+			return nil, err
+		}
+		if second == 0 {
+			rest, err := r.ReadBits(14)
+			if err != nil {
+				return nil, err
+			}
+			assignVal = rest
+		} else {
+			third, err := r.ReadBits(1)
+			if err != nil {
+				return nil, err
+			}
+			if third != 0 {
+				return nil, fmt.Errorf("qrsegment: invalid ECI designator prefix 111")
+			}
+			rest, err := r.ReadBits(21)
+			if err != nil {
+				return nil, err
+			}
+			assignVal = rest
+		}
+	}
+	return MakeEci(assignVal), nil
+}
+
+// decodeStructuredAppendSegment reads the fixed 16-bit index/total/parity
+// fields that MakeStructuredAppend writes.
+func decodeStructuredAppendSegment(r *BitReader) (*QrSegment, error) {
+	index, err := r.ReadBits(4)
+	if err != nil {
+		return nil, err
+	}
+	totalMinus1, err := r.ReadBits(4)
+	if err != nil {
+		return nil, err
+	}
+	parity, err := r.ReadBits(8)
+	if err != nil {
+		return nil, err
+	}
+	return MakeStructuredAppend(int(index), int(totalMinus1)+1, byte(parity)), nil
+}
+
+// SegmentsToText concatenates the decoded character content of segs into a
+// single Unicode string, tracking ECI designators to interpret Byte mode
+// segments in the charset they announce. Byte mode segments before any ECI
+// designator are assumed to hold UTF-8, matching what EncodeText produces.
+//
+// Returns an error if a segment has malformed numeric, alphanumeric, or
+// kanji data, or announces an ECI charset this package doesn't recognize.
+func SegmentsToText(segs []*QrSegment) (string, error) {
+	var sb strings.Builder
+	charset := UTF8
+	for _, seg := range segs {
+		switch seg.mode {
+		case Eci:
+			assignVal, err := eciAssignmentFromData(seg.data)
+			if err != nil {
+				return "", err
+			}
+			cs, ok := charsetFromAssignment(assignVal)
+			if !ok {
+				return "", fmt.Errorf("qrsegment: unrecognized ECI assignment value %d", assignVal)
+			}
+			charset = cs
+		case StructuredAppend:
+			// Carries no text content.
+		case Numeric:
+			digits, err := decodeNumericDigits(seg.data, seg.numChars)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(digits)
+		case Alphanumeric:
+			text, err := decodeAlphanumericText(seg.data, seg.numChars)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(text)
+		case Byte:
+			text, err := decodeByteText(seg.data, charset)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(text)
+		case Kanji:
+			text, err := decodeKanjiText(seg.data, seg.numChars)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(text)
+		default:
+			return "", fmt.Errorf("qrsegment: invalid mode %v", seg.mode)
+		}
+	}
+	return sb.String(), nil
+}
+
+func decodeNumericDigits(data []bool, numChars int) (string, error) {
+	r := NewBitReader(boolsToBytes(data))
+	var sb strings.Builder
+	remaining := numChars
+	for remaining > 0 {
+		group := 3
+		width := 10
+		if remaining < 3 {
+			group = remaining
+			width = group*3 + 1
+		}
+		val, err := r.ReadBits(width)
+		if err != nil {
+			return "", fmt.Errorf("qrsegment: numeric segment: %w", err)
+		}
+		digits := fmt.Sprintf("%0*d", group, val)
+		if len(digits) != group {
+			return "", fmt.Errorf("qrsegment: numeric segment has a value too large for its digit group")
+		}
+		sb.WriteString(digits)
+		remaining -= group
+	}
+	return sb.String(), nil
+}
+
+func decodeAlphanumericText(data []bool, numChars int) (string, error) {
+	r := NewBitReader(boolsToBytes(data))
+	var sb strings.Builder
+	remaining := numChars
+	for remaining >= 2 {
+		val, err := r.ReadBits(11)
+		if err != nil {
+			return "", fmt.Errorf("qrsegment: alphanumeric segment: %w", err)
+		}
+		if val >= 45*45 {
+			return "", fmt.Errorf("qrsegment: alphanumeric segment has an out-of-range character pair")
+		}
+		sb.WriteByte(alphanumericCharset[val/45])
+		sb.WriteByte(alphanumericCharset[val%45])
+		remaining -= 2
+	}
+	if remaining == 1 {
+		val, err := r.ReadBits(6)
+		if err != nil {
+			return "", fmt.Errorf("qrsegment: alphanumeric segment: %w", err)
+		}
+		if int(val) >= len(alphanumericCharset) {
+			return "", fmt.Errorf("qrsegment: alphanumeric segment has an out-of-range character")
+		}
+		sb.WriteByte(alphanumericCharset[val])
+	}
+	return sb.String(), nil
+}
+
+func decodeKanjiText(data []bool, numChars int) (string, error) {
+	r := NewBitReader(boolsToBytes(data))
+	var sb strings.Builder
+	for i := 0; i < numChars; i++ {
+		val, err := r.ReadBits(13)
+		if err != nil {
+			return "", fmt.Errorf("qrsegment: kanji segment: %w", err)
+		}
+		c, ok := kanjiValueToRune(uint16(val))
+		if !ok {
+			return "", fmt.Errorf("qrsegment: kanji segment has an unrecognized value %d", val)
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String(), nil
+}
+
+func decodeByteText(data []bool, charset Charset) (string, error) {
+	raw := boolsToBytes(data)
+	switch charset {
+	case UTF8:
+		return string(raw), nil
+	case ISO88591:
+		runes := make([]rune, len(raw))
+		for i, b := range raw {
+			runes[i] = rune(b)
+		}
+		return string(runes), nil
+	default:
+		return "", fmt.Errorf("qrsegment: decoding byte mode data in charset %v is not yet supported", charset)
+	}
+}
+
+func boolsToBytes(data []bool) []byte {
+	result := make([]byte, (len(data)+7)/8)
+	for i, bit := range data {
+		if bit {
+			result[i/8] |= 1 << uint(7-i%8)
+		}
+	}
+	return result
+}
+
+// eciAssignmentFromData re-derives the assignment value that decodeEciSegment
+// parsed, from the raw data bits an Eci-mode QrSegment stores.
+func eciAssignmentFromData(data []bool) (uint32, error) {
+	r := NewBitReader(boolsToBytes(data))
+	switch len(data) {
+	case 8:
+		val, err := r.ReadBits(8)
+		return val, err
+	case 16:
+		if _, err := r.ReadBits(2); err != nil {
+			return 0, err
+		}
+		return r.ReadBits(14)
+	case 24:
+		if _, err := r.ReadBits(3); err != nil {
+			return 0, err
+		}
+		return r.ReadBits(21)
+	default:
+		return 0, fmt.Errorf("qrsegment: ECI segment has invalid bit length %d", len(data))
+	}
+}
+
+// charsetFromAssignment reverses Charset.EciAssignment for the charsets this
+// package recognizes.
+func charsetFromAssignment(assignVal uint32) (Charset, bool) {
+	for cs, val := range eciAssignments {
+		if val == assignVal {
+			return cs, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,115 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrsegment
+
+// Charset identifies a character encoding recognized by the Extended
+// Channel Interpretation (ECI) mechanism, for use with MakeEciSegments.
+type Charset int
+
+const (
+	ISO88591 Charset = iota
+	ISO88592
+	ISO88593
+	ISO88594
+	ISO88595
+	ISO88596
+	ISO88597
+	ISO88598
+	ISO88599
+	ISO885910
+	ISO885911
+	ISO885913
+	ISO885914
+	ISO885915
+	ISO885916
+	ShiftJIS
+	Windows1252
+	UTF16BE
+	UTF8
+)
+
+// eciAssignments maps each Charset to its ECI assignment number, per the
+// AIM ECI registry.
+var eciAssignments = map[Charset]uint32{
+	ISO88591:    3,
+	ISO88592:    4,
+	ISO88593:    5,
+	ISO88594:    6,
+	ISO88595:    7,
+	ISO88596:    8,
+	ISO88597:    9,
+	ISO88598:    10,
+	ISO88599:    11,
+	ISO885910:   12,
+	ISO885911:   13,
+	ISO885913:   15,
+	ISO885914:   16,
+	ISO885915:   17,
+	ISO885916:   18,
+	ShiftJIS:    20,
+	Windows1252: 23,
+	UTF16BE:     25,
+	UTF8:        26,
+}
+
+var charsetNames = map[Charset]string{
+	ISO88591:    "ISO-8859-1",
+	ISO88592:    "ISO-8859-2",
+	ISO88593:    "ISO-8859-3",
+	ISO88594:    "ISO-8859-4",
+	ISO88595:    "ISO-8859-5",
+	ISO88596:    "ISO-8859-6",
+	ISO88597:    "ISO-8859-7",
+	ISO88598:    "ISO-8859-8",
+	ISO88599:    "ISO-8859-9",
+	ISO885910:   "ISO-8859-10",
+	ISO885911:   "ISO-8859-11",
+	ISO885913:   "ISO-8859-13",
+	ISO885914:   "ISO-8859-14",
+	ISO885915:   "ISO-8859-15",
+	ISO885916:   "ISO-8859-16",
+	ShiftJIS:    "Shift-JIS",
+	Windows1252: "Windows-1252",
+	UTF16BE:     "UTF-16",
+	UTF8:        "UTF-8",
+}
+
+// EciAssignment returns the ECI assignment number for the charset, as
+// defined by the AIM ECI registry.
+//
+// Panics if c is not one of the named Charset constants.
+func (c Charset) EciAssignment() uint32 {
+	val, ok := eciAssignments[c]
+	if !ok {
+		panic("invalid charset")
+	}
+	return val
+}
+
+// String returns the common name of the charset, such as "ISO-8859-1" or
+// "UTF-8".
+func (c Charset) String() string {
+	name, ok := charsetNames[c]
+	if !ok {
+		panic("invalid charset")
+	}
+	return name
+}
+
+// MakeEciSegments returns a pair of segments — an ECI designator for
+// charset followed by a byte-mode segment holding data — so that a
+// conforming reader decodes data using charset instead of the default
+// interpretation.
+//
+// data must already be encoded in charset; this function does not
+// transcode text. It exists so that callers who have bytes in a
+// particular charset don't need to look up or remember its ECI assignment
+// number, as MakeEci requires.
+func MakeEciSegments(data []byte, charset Charset) []*QrSegment {
+	return []*QrSegment{MakeEci(charset.EciAssignment()), MakeBytes(data)}
+}
@@ -0,0 +1,121 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrsegment
+
+import "strings"
+
+// eciRunCategory classifies a maximal run of characters by the cheapest
+// native encoding splitEciRuns found for it.
+type eciRunCategory int
+
+const (
+	eciRunPlain eciRunCategory = iota
+	eciRunKanji
+	eciRunLatin1
+)
+
+type eciRun struct {
+	text     string
+	category eciRunCategory
+}
+
+// MakeSegmentsWithEci is like MakeSegments, but for text that MakeSegments
+// would fall back to a single UTF-8 byte-mode segment for, also considers
+// splitting text into same-charset runs joined by ECI designators (e.g. a
+// Latin-1 run followed by a kanji run), returning whichever encoding has
+// the fewer total bits at version ver.
+//
+// International payloads that mix scripts (accented Latin text next to
+// Japanese text is a common case) otherwise pay the full UTF-8 byte-mode
+// cost for every character, even where a narrower charset or kanji mode
+// would encode the same run in fewer bits.
+func MakeSegmentsWithEci(text string, ver Version) []*QrSegment {
+	if text == "" {
+		return nil
+	}
+	if IsNumeric(text) {
+		return []*QrSegment{MakeNumeric(text)}
+	}
+	if IsAlphanumeric(text) {
+		return []*QrSegment{MakeAlphanumeric(text)}
+	}
+
+	runs := splitEciRuns(text)
+	plain := []*QrSegment{MakeBytes([]byte(text))}
+	if len(runs) <= 1 {
+		return plain
+	}
+
+	var multi []*QrSegment
+	latin1Active := false
+	for _, r := range runs {
+		switch r.category {
+		case eciRunKanji:
+			multi = append(multi, MakeKanji(r.text))
+		case eciRunLatin1:
+			multi = append(multi, MakeEciSegments(latin1Bytes(r.text), ISO88591)...)
+			latin1Active = true
+		default:
+			if latin1Active {
+				// Byte mode is still ISO-8859-1 here; switch it back to UTF-8.
+				multi = append(multi, MakeEci(UTF8.EciAssignment()))
+				latin1Active = false
+			}
+			multi = append(multi, MakeBytes([]byte(r.text)))
+		}
+	}
+
+	multiBits, multiOk := GetTotalBits(multi, ver)
+	plainBits, plainOk := GetTotalBits(plain, ver)
+	if multiOk && (!plainOk || multiBits < plainBits) {
+		return multi
+	}
+	return plain
+}
+
+// splitEciRuns groups text into maximal runs of characters encodable the
+// same way: kanji mode, ISO-8859-1 (which also covers plain ASCII), or
+// neither (requiring UTF-8 byte mode).
+func splitEciRuns(text string) []eciRun {
+	var runs []eciRun
+	var cur strings.Builder
+	curCategory := eciRunPlain
+	flush := func() {
+		if cur.Len() > 0 {
+			runs = append(runs, eciRun{text: cur.String(), category: curCategory})
+			cur.Reset()
+		}
+	}
+	for _, c := range text {
+		category := eciRunPlain
+		if _, ok := runeToKanjiValue(c); ok {
+			category = eciRunKanji
+		} else if c <= 0xFF {
+			category = eciRunLatin1
+		}
+		if cur.Len() > 0 && category != curCategory {
+			flush()
+		}
+		curCategory = category
+		cur.WriteRune(c)
+	}
+	flush()
+	return runs
+}
+
+// latin1Bytes encodes text as ISO-8859-1, where each character's byte value
+// equals its Unicode code point. The caller must have already verified
+// every character is in [0, 0xFF], e.g. via splitEciRuns's eciRunLatin1
+// category.
+func latin1Bytes(text string) []byte {
+	b := make([]byte, 0, len(text))
+	for _, c := range text {
+		b = append(b, byte(c))
+	}
+	return b
+}
@@ -0,0 +1,94 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrsegment
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestMakeKanjiCheckedEncodableText checks that MakeKanjiChecked accepts an
+// encodable string and packs its characters into the documented 13 bits
+// each, matching its NumChars and the value runeToKanjiValue independently
+// reports for each character.
+func TestMakeKanjiCheckedEncodableText(t *testing.T) {
+	const text = "点茗" // two characters known to be in the JIS X 0208 table
+	seg, err := MakeKanjiChecked(text)
+	if err != nil {
+		t.Fatalf("MakeKanjiChecked(%q) returned an error: %v", text, err)
+	}
+	if seg.Mode() != Kanji {
+		t.Errorf("Mode() = %v, want Kanji", seg.Mode())
+	}
+	if seg.NumChars() != 2 {
+		t.Errorf("NumChars() = %d, want 2", seg.NumChars())
+	}
+	data := seg.Data()
+	if len(data) != 2*13 {
+		t.Fatalf("len(Data()) = %d, want %d", len(data), 2*13)
+	}
+	i := 0
+	for _, c := range text {
+		want, ok := runeToKanjiValue(c)
+		if !ok {
+			t.Fatalf("runeToKanjiValue(%q) reported not encodable; test fixture is wrong", c)
+		}
+		var got uint32
+		for _, bit := range data[i*13 : i*13+13] {
+			got = got<<1 | b2u32(bit)
+		}
+		if got != uint32(want) {
+			t.Errorf("character %d (%q): packed value %d, want %d", i, c, got, want)
+		}
+		i++
+	}
+}
+
+// TestMakeKanjiCheckedRejectsUnencodable checks that MakeKanjiChecked
+// returns an *ErrInvalidCharacter identifying the first unencodable
+// character's byte offset, rather than panicking, matching every sibling
+// constructor in this package (MakeNumericChecked, MakeAlphanumericChecked,
+// MakeEciChecked, MakeStructuredAppendChecked).
+func TestMakeKanjiCheckedRejectsUnencodable(t *testing.T) {
+	const text = "点A" // ordinary ASCII is not encodable in kanji mode
+	_, err := MakeKanjiChecked(text)
+	if err == nil {
+		t.Fatal("MakeKanjiChecked with an unencodable character returned no error")
+	}
+	invalid, ok := err.(*ErrInvalidCharacter)
+	if !ok {
+		t.Fatalf("MakeKanjiChecked returned %v (%T), want *ErrInvalidCharacter", err, err)
+	}
+	if invalid.Pos != strings.Index(text, "A") || invalid.Rune != 'A' || invalid.Mode != Kanji {
+		t.Errorf("got %+v, want Pos=%d Rune='A' Mode=Kanji", invalid, strings.Index(text, "A"))
+	}
+}
+
+// TestMakeKanjiPanicsOnUnencodable checks that MakeKanji panics (rather
+// than silently truncating or mis-encoding) on a string MakeKanjiChecked
+// would reject, and that IsKanji agrees with MakeKanjiChecked about which
+// strings are encodable.
+func TestMakeKanjiPanicsOnUnencodable(t *testing.T) {
+	const text = "hello"
+	if IsKanji(text) {
+		t.Fatalf("IsKanji(%q) = true; test fixture is wrong", text)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("MakeKanji did not panic on an unencodable string")
+		}
+	}()
+	MakeKanji(text)
+}
+
+func b2u32(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrsegment
+
+import "strings"
+
+// ConcatSegments flattens any number of segment lists into one, in order,
+// for composing a payload out of several builders' outputs (e.g. a fixed
+// prefix, a variable identifier, and a fixed suffix). It does not merge
+// adjacent segments that share a mode; call MergeAdjacentSegments on the
+// result if that's desired.
+func ConcatSegments(lists ...[]*QrSegment) []*QrSegment {
+	n := 0
+	for _, l := range lists {
+		n += len(l)
+	}
+	out := make([]*QrSegment, 0, n)
+	for _, l := range lists {
+		out = append(out, l...)
+	}
+	return out
+}
+
+// MergeAdjacentSegments returns segs with every maximal run of consecutive
+// Numeric, Alphanumeric, Byte, or Kanji segments collapsed into one segment
+// of that mode, recomputing the character count from the run. This saves
+// the fixed per-segment header (mode indicator and character count field)
+// that splitting a payload across several builders would otherwise repeat.
+//
+// Eci and StructuredAppend segments are never merged with their neighbors,
+// since each one carries its own distinct meaning rather than a run of
+// characters.
+func MergeAdjacentSegments(segs []*QrSegment) []*QrSegment {
+	var out []*QrSegment
+	for i := 0; i < len(segs); {
+		j := i + 1
+		mode := segs[i].mode
+		if mode == Numeric || mode == Alphanumeric || mode == Byte || mode == Kanji {
+			for j < len(segs) && segs[j].mode == mode {
+				j++
+			}
+		}
+		out = append(out, mergeRun(segs[i:j]))
+		i = j
+	}
+	return out
+}
+
+// mergeRun combines a non-empty run of same-mode segments into one segment.
+// For Numeric and Alphanumeric, the run is decoded back to text and
+// re-encoded as a whole, because those modes pack characters in groups
+// (of 3 digits or 2 alphanumeric characters) whose boundaries depend on the
+// total run length, not just on concatenating each segment's own bits. Byte
+// and Kanji have no such cross-character grouping, so their data bits can
+// be concatenated directly.
+func mergeRun(run []*QrSegment) *QrSegment {
+	if len(run) == 1 {
+		return run[0]
+	}
+	switch run[0].mode {
+	case Numeric:
+		var digits strings.Builder
+		for _, seg := range run {
+			s, err := decodeNumericDigits(seg.data, seg.numChars)
+			if err != nil {
+				panic(err)
+			}
+			digits.WriteString(s)
+		}
+		return MakeNumeric(digits.String())
+	case Alphanumeric:
+		var text strings.Builder
+		for _, seg := range run {
+			s, err := decodeAlphanumericText(seg.data, seg.numChars)
+			if err != nil {
+				panic(err)
+			}
+			text.WriteString(s)
+		}
+		return MakeAlphanumeric(text.String())
+	default: // Byte, Kanji
+		numChars := 0
+		var data []bool
+		for _, seg := range run {
+			numChars += seg.numChars
+			data = append(data, seg.data...)
+		}
+		return &QrSegment{mode: run[0].mode, numChars: numChars, data: data}
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrsegment
+
+import "io"
+
+// NumericSegmentBuilder accumulates digits incrementally and finalizes them
+// into a single numeric-mode segment, for payloads (e.g. SHC-style health
+// cards) with multi-thousand-digit numeric bodies that a caller would
+// otherwise have to materialize twice: once as the assembled string, and
+// again as MakeNumericChecked's bit buffer.
+//
+// The zero value is not usable; construct one with NewNumericSegmentBuilder.
+type NumericSegmentBuilder struct {
+	data       []bool
+	numChars   int
+	accumData  uint32
+	accumCount int
+}
+
+// NewNumericSegmentBuilder returns an empty NumericSegmentBuilder.
+func NewNumericSegmentBuilder() *NumericSegmentBuilder {
+	return &NumericSegmentBuilder{}
+}
+
+// WriteDigits appends digits to the builder, packing every complete group
+// of three into 10 bits the same way MakeNumericChecked does.
+//
+// Returns an *ErrInvalidCharacter, with Pos relative to this call's digits
+// string, if digits contains a non-digit character; the builder's state
+// from before the call is unaffected by an error partway through.
+func (b *NumericSegmentBuilder) WriteDigits(digits string) error {
+	for pos, c := range digits {
+		if c < '0' || c > '9' {
+			return &ErrInvalidCharacter{Pos: pos, Rune: c, Mode: Numeric}
+		}
+	}
+	for _, c := range digits {
+		b.writeDigit(uint32(c - '0'))
+	}
+	return nil
+}
+
+// ReadFrom reads digit bytes from r until EOF, satisfying io.ReaderFrom so
+// a builder can be populated directly from a streaming source, such as a
+// large file, without first reading it into a string.
+//
+// Returns the number of bytes consumed and an *ErrInvalidCharacter (with
+// Pos counting from the start of this call) if r yields a non-digit byte,
+// or any error returned by r itself.
+func (b *NumericSegmentBuilder) ReadFrom(r io.Reader) (int64, error) {
+	var buf [4096]byte
+	var total int64
+	for {
+		n, err := r.Read(buf[:])
+		for i := 0; i < n; i++ {
+			c := buf[i]
+			if c < '0' || c > '9' {
+				return total, &ErrInvalidCharacter{Pos: int(total), Rune: rune(c), Mode: Numeric}
+			}
+			b.writeDigit(uint32(c - '0'))
+			total++
+		}
+		if err == io.EOF {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// writeDigit appends a single decimal digit's value, flushing a completed
+// group of three into 10 bits of data.
+func (b *NumericSegmentBuilder) writeDigit(digit uint32) {
+	b.accumData = b.accumData*10 + digit
+	b.accumCount++
+	b.numChars++
+	if b.accumCount == 3 {
+		b.data = appendBits(b.data, b.accumData, 10)
+		b.accumData = 0
+		b.accumCount = 0
+	}
+}
+
+// Build finalizes the digits written so far into a numeric-mode segment,
+// flushing a trailing group of 1 or 2 digits the same way
+// MakeNumericChecked does. The builder remains usable afterward; Build
+// does not reset or consume its state.
+func (b *NumericSegmentBuilder) Build() *QrSegment {
+	data := append([]bool(nil), b.data...)
+	if b.accumCount > 0 {
+		data = appendBits(data, b.accumData, b.accumCount*3+1)
+	}
+	return &QrSegment{mode: Numeric, numChars: b.numChars, data: data}
+}
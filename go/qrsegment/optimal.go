@@ -0,0 +1,160 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrsegment
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+// optimalModes is every mode MakeSegmentsOptimal considers for a single
+// character, in a fixed order used to index the dynamic programming
+// tables below.
+var optimalModes = [4]QrSegmentMode{Numeric, Alphanumeric, Byte, Kanji}
+
+// sixthBitUnit scales every cost in MakeSegmentsOptimal's dynamic program
+// by 6, the smallest common denominator of numeric mode's 10/3 bits per
+// character and alphanumeric mode's 11/2 bits per character, so the whole
+// computation can run in integers instead of floating point.
+const sixthBitUnit = 6
+
+// infeasibleCost stands in for "this character cannot be encoded in this
+// mode" in the cost tables below. It is added to at most n times along any
+// path (n being the text length), so it must stay far below
+// math.MaxInt/n for any text length this package would realistically see.
+const infeasibleCost = 1 << 40
+
+// MakeSegmentsOptimal returns the list of segments that encode text using
+// the fewest total bits at version ver, by running a per-character dynamic
+// program over all four character-carrying modes (Numeric, Alphanumeric,
+// Byte, Kanji) rather than MakeSegments' whole-string mode choice. This
+// finds the genuinely minimal encoding for text that mixes scripts, such
+// as Japanese prose with an embedded ASCII URL, instead of falling back to
+// byte mode for the entire string merely because one character needs it.
+//
+// The per-character costs used to choose mode boundaries are exact for
+// Byte and Kanji, and exact up to the well-known rounding at a segment's
+// last one or two characters for Numeric and Alphanumeric (the same
+// rounding MakeNumericChecked/MakeAlphanumericChecked apply); the segments
+// actually returned are built by those same functions, so their bit
+// packing is always exact regardless.
+//
+// Returns an error if text is not valid UTF-8.
+func MakeSegmentsOptimal(text string, ver Version) ([]*QrSegment, error) {
+	if !utf8.ValidString(text) {
+		return nil, errors.New("qrsegment: text is not valid UTF-8")
+	}
+	runes := []rune(text)
+	if len(runes) == 0 {
+		return nil, nil
+	}
+
+	headCost := [4]int{}
+	for j, m := range optimalModes {
+		headCost[j] = sixthBitUnit * (4 + m.NumCharCountBits(ver))
+	}
+
+	// charCost[i][j] is the number of 1/6-bit units runes[i] costs to pack
+	// as data if included in a run of mode optimalModes[j], or
+	// infeasibleCost if that mode cannot encode it at all.
+	charCost := make([][4]int, len(runes))
+	for i, c := range runes {
+		charCost[i][0] = infeasibleCost
+		if c >= '0' && c <= '9' {
+			charCost[i][0] = 20 // 10/3 bits/char
+		}
+		charCost[i][1] = infeasibleCost
+		if indexInAlphanumericCharset(c) >= 0 {
+			charCost[i][1] = 33 // 11/2 bits/char
+		}
+		charCost[i][2] = sixthBitUnit * 8 * utf8.RuneLen(c) // Byte mode always applies.
+		charCost[i][3] = infeasibleCost
+		if _, ok := runeToKanjiValue(c); ok {
+			charCost[i][3] = 78 // 13 bits/char
+		}
+	}
+
+	// dp[i][j] is the minimum total cost, in 1/6-bit units, of encoding
+	// runes[0..i] such that runes[i] is the last character of a run in
+	// mode optimalModes[j] (including that run's not-yet-amortized header
+	// cost). from[i][j] is which mode index runes[i-1] ended in along that
+	// optimal path: j itself means the run continues, any other value k
+	// means runes[i] started a fresh run right after a run ending in mode
+	// k.
+	dp := make([][4]int, len(runes))
+	from := make([][4]int, len(runes))
+	for j := range optimalModes {
+		dp[0][j] = headCost[j] + charCost[0][j]
+		from[0][j] = j
+	}
+	for i := 1; i < len(runes); i++ {
+		prevBest, prevBestMode := dp[i-1][0], 0
+		for j := 1; j < len(optimalModes); j++ {
+			if dp[i-1][j] < prevBest {
+				prevBest, prevBestMode = dp[i-1][j], j
+			}
+		}
+		for j := range optimalModes {
+			continued := dp[i-1][j]
+			switched := prevBest + headCost[j]
+			if prevBestMode == j {
+				// The global best at i-1 is already mode j, so switching
+				// "into" j from it is really just continuing; don't
+				// double-charge a header that continued already omits.
+				switched = continued
+			}
+			if continued <= switched {
+				dp[i][j] = continued + charCost[i][j]
+				from[i][j] = j
+			} else {
+				dp[i][j] = switched + charCost[i][j]
+				from[i][j] = prevBestMode
+			}
+		}
+	}
+
+	last := len(runes) - 1
+	bestMode := 0
+	for j := 1; j < len(optimalModes); j++ {
+		if dp[last][j] < dp[last][bestMode] {
+			bestMode = j
+		}
+	}
+
+	// Backtrack to recover each character's mode, then split into maximal
+	// runs and build the actual segments.
+	charModes := make([]int, len(runes))
+	mode := bestMode
+	for i := last; i >= 0; i-- {
+		charModes[i] = mode
+		if from[i][mode] != mode {
+			mode = from[i][mode]
+		}
+	}
+
+	var segs []*QrSegment
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && charModes[j] == charModes[i] {
+			j++
+		}
+		run := string(runes[i:j])
+		switch optimalModes[charModes[i]] {
+		case Numeric:
+			segs = append(segs, MakeNumeric(run))
+		case Alphanumeric:
+			segs = append(segs, MakeAlphanumeric(run))
+		case Kanji:
+			segs = append(segs, MakeKanji(run))
+		default:
+			segs = append(segs, MakeBytes([]byte(run)))
+		}
+		i = j
+	}
+	return segs, nil
+}
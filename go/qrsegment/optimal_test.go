@@ -0,0 +1,125 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrsegment
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestMakeSegmentsOptimalEmpty checks that an empty string produces no
+// segments and no error, matching MakeSegments.
+func TestMakeSegmentsOptimalEmpty(t *testing.T) {
+	segs, err := MakeSegmentsOptimal("", 1)
+	if err != nil {
+		t.Fatalf("MakeSegmentsOptimal(\"\") returned an error: %v", err)
+	}
+	if segs != nil {
+		t.Errorf("MakeSegmentsOptimal(\"\") = %v, want nil", segs)
+	}
+}
+
+// TestMakeSegmentsOptimalRejectsInvalidUTF8 checks that malformed UTF-8 is
+// reported as an error instead of being misread rune by rune.
+func TestMakeSegmentsOptimalRejectsInvalidUTF8(t *testing.T) {
+	if _, err := MakeSegmentsOptimal("abc\xFFdef", 1); err == nil {
+		t.Error("MakeSegmentsOptimal with invalid UTF-8 returned no error")
+	}
+}
+
+// TestMakeSegmentsOptimalSingleModeText checks that text encodable entirely
+// in one mode is packed as a single segment of that mode, matching
+// MakeSegments' whole-string choice, and that every character is accounted
+// for.
+func TestMakeSegmentsOptimalSingleModeText(t *testing.T) {
+	tests := []struct {
+		text string
+		mode QrSegmentMode
+	}{
+		{"0123456789", Numeric},
+		{"HELLO WORLD 123", Alphanumeric},
+		{"hello, world!", Byte},
+	}
+	for _, test := range tests {
+		segs, err := MakeSegmentsOptimal(test.text, 10)
+		if err != nil {
+			t.Fatalf("MakeSegmentsOptimal(%q) returned an error: %v", test.text, err)
+		}
+		if len(segs) != 1 {
+			t.Fatalf("MakeSegmentsOptimal(%q) = %d segments, want 1", test.text, len(segs))
+		}
+		if segs[0].Mode() != test.mode {
+			t.Errorf("MakeSegmentsOptimal(%q) mode = %v, want %v", test.text, segs[0].Mode(), test.mode)
+		}
+		if segs[0].NumChars() != len([]rune(test.text)) {
+			t.Errorf("MakeSegmentsOptimal(%q) NumChars() = %d, want %d", test.text, segs[0].NumChars(), len([]rune(test.text)))
+		}
+	}
+}
+
+// TestMakeSegmentsOptimalSwitchesModeForMixedText checks that text mixing
+// an ASCII run with kanji splits into distinct segments, one per script,
+// rather than falling back to byte mode for the entire string the way
+// MakeSegments would.
+func TestMakeSegmentsOptimalSwitchesModeForMixedText(t *testing.T) {
+	const ascii = "https://example.com/"
+	const kanji = "点茗" // characters known to be in the JIS X 0208 table
+	text := ascii + kanji
+	segs, err := MakeSegmentsOptimal(text, 10)
+	if err != nil {
+		t.Fatalf("MakeSegmentsOptimal(%q) returned an error: %v", text, err)
+	}
+	if len(segs) != 2 {
+		t.Fatalf("MakeSegmentsOptimal(%q) = %d segments, want 2; got %v", text, len(segs), segs)
+	}
+	if segs[1].Mode() != Kanji {
+		t.Errorf("MakeSegmentsOptimal(%q) second segment mode = %v, want Kanji", text, segs[1].Mode())
+	}
+	if segs[1].NumChars() != len([]rune(kanji)) {
+		t.Errorf("MakeSegmentsOptimal(%q) second segment NumChars() = %d, want %d", text, segs[1].NumChars(), len([]rune(kanji)))
+	}
+
+	var total int
+	for _, seg := range segs {
+		total += seg.NumChars()
+	}
+	if want := len([]rune(text)); total != want {
+		t.Errorf("segments' NumChars() sum to %d characters, want %d", total, want)
+	}
+}
+
+// TestMakeSegmentsOptimalNeverCostsMoreThanWholeStringChoice checks that
+// the dynamic program never does worse than MakeSegments' single
+// whole-string mode, across a range of versions, for mixed text where a
+// single byte-mode run would otherwise dominate.
+func TestMakeSegmentsOptimalNeverCostsMoreThanWholeStringChoice(t *testing.T) {
+	texts := []string{
+		"ABC123 https://example.com/path?x=1",
+		strings.Repeat("9", 30) + "HELLO",
+		"plain ascii text, nothing special here.",
+	}
+	for _, text := range texts {
+		for _, ver := range []Version{1, 10, 40} {
+			optimalSegs, err := MakeSegmentsOptimal(text, ver)
+			if err != nil {
+				t.Fatalf("MakeSegmentsOptimal(%q, %d) returned an error: %v", text, ver, err)
+			}
+			optimalBits, ok := GetTotalBits(optimalSegs, ver)
+			if !ok {
+				t.Fatalf("GetTotalBits for MakeSegmentsOptimal(%q, %d) reported not fitting", text, ver)
+			}
+			wholeStringBits, ok := GetTotalBits(MakeSegments(text), ver)
+			if !ok {
+				t.Fatalf("GetTotalBits for MakeSegments(%q) reported not fitting at version %d", text, ver)
+			}
+			if optimalBits > wholeStringBits {
+				t.Errorf("MakeSegmentsOptimal(%q, %d) costs %d bits, more than MakeSegments' %d bits", text, ver, optimalBits, wholeStringBits)
+			}
+		}
+	}
+}
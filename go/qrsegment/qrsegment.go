@@ -0,0 +1,632 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ * - The above copyright notice and this permission notice shall be included in
+ *   all copies or substantial portions of the Software.
+ * - The Software is provided "as is", without warranty of any kind, express or
+ *   implied, including but not limited to the warranties of merchantability,
+ *   fitness for a particular purpose and noninfringement. In no event shall the
+ *   authors or copyright holders be liable for any claim, damages or other
+ *   liability, whether in an action of contract, tort or otherwise, arising from,
+ *   out of or in connection with the Software or the use or other dealings in the
+ *   Software.
+ */
+
+// Package qrsegment represents the segments of character/binary/control data
+// that make up the payload of a QR Code symbol.
+package qrsegment
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Version is a QR Code version number, between 1 and 40 (inclusive).
+type Version int32
+
+// MinVersion and MaxVersion are the smallest and largest version numbers
+// supported by the QR Code Model 2 standard.
+const (
+	MinVersion Version = 1
+	MaxVersion Version = 40
+)
+
+// QrSegmentMode describes how a segment's data bits are interpreted.
+type QrSegmentMode int
+
+const (
+	Numeric QrSegmentMode = iota
+	Alphanumeric
+	Byte
+	Kanji
+	Eci
+	StructuredAppend
+)
+
+// String returns a human-readable name for the mode, such as "Numeric".
+func (m QrSegmentMode) String() string {
+	switch m {
+	case Numeric:
+		return "Numeric"
+	case Alphanumeric:
+		return "Alphanumeric"
+	case Byte:
+		return "Byte"
+	case Kanji:
+		return "Kanji"
+	case Eci:
+		return "Eci"
+	case StructuredAppend:
+		return "StructuredAppend"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseMode parses a mode name as returned by QrSegmentMode.String (e.g.
+// "Numeric", case-insensitive), for tooling that round-trips segment plans
+// through a human-readable config file.
+//
+// Returns an error if name does not match any mode.
+func ParseMode(name string) (QrSegmentMode, error) {
+	for m := Numeric; m <= StructuredAppend; m++ {
+		if strings.EqualFold(m.String(), name) {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("qrsegment: %q is not a valid segment mode name", name)
+}
+
+// IsCharacterMode reports whether m encodes user-visible text or binary
+// payload data (Numeric, Alphanumeric, Byte, or Kanji), as opposed to a
+// control mode that changes how later segments are interpreted.
+func (m QrSegmentMode) IsCharacterMode() bool {
+	switch m {
+	case Numeric, Alphanumeric, Byte, Kanji:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsControlMode reports whether m changes how other segments are
+// interpreted rather than carrying payload data itself (Eci or
+// StructuredAppend).
+func (m QrSegmentMode) IsControlMode() bool {
+	switch m {
+	case Eci, StructuredAppend:
+		return true
+	default:
+		return false
+	}
+}
+
+// ErrInvalidCharacter reports that a string passed to a mode-specific
+// constructor, such as MakeNumericChecked or MakeAlphanumericChecked,
+// contains a character outside the set that mode can encode.
+type ErrInvalidCharacter struct {
+	// Pos is the byte offset of Rune within the rejected string.
+	Pos int
+
+	// Rune is the invalid character itself.
+	Rune rune
+
+	// Mode is the segment mode that rejected Rune.
+	Mode QrSegmentMode
+}
+
+func (e *ErrInvalidCharacter) Error() string {
+	return fmt.Sprintf("qrsegment: character %q at byte offset %d is not encodable in %v mode", e.Rune, e.Pos, e.Mode)
+}
+
+// ModeBits returns the 4-bit mode indicator for this mode, as used in the
+// segment header written into a QR Code's data stream.
+func (m QrSegmentMode) ModeBits() uint32 {
+	switch m {
+	case Numeric:
+		return 0x1
+	case Alphanumeric:
+		return 0x2
+	case Byte:
+		return 0x4
+	case Kanji:
+		return 0x8
+	case Eci:
+		return 0x7
+	case StructuredAppend:
+		return 0x3
+	default:
+		panic("invalid mode")
+	}
+}
+
+// NumCharCountBits returns the bit width of the character count field for a
+// segment in this mode in a QR Code of the given version number. The result
+// is in the range [0, 16].
+func (m QrSegmentMode) NumCharCountBits(ver Version) int {
+	var widths [3]int
+	switch m {
+	case Numeric:
+		widths = [3]int{10, 12, 14}
+	case Alphanumeric:
+		widths = [3]int{9, 11, 13}
+	case Byte:
+		widths = [3]int{8, 16, 16}
+	case Kanji:
+		widths = [3]int{8, 10, 12}
+	case Eci:
+		widths = [3]int{0, 0, 0}
+	case StructuredAppend:
+		widths = [3]int{0, 0, 0}
+	default:
+		panic("invalid mode")
+	}
+	return widths[(int(ver)+7)/17]
+}
+
+// alphanumericCharset is the set of all legal characters in alphanumeric
+// mode, where each character's index in the string is its encoded value.
+const alphanumericCharset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// QrSegment is a segment of character/binary/control data in a QR Code symbol.
+//
+// Instances of this type are immutable after construction: New and
+// NewChecked copy the data slice they are given rather than retain the
+// caller's backing array, and Data copies again on the way out, so neither
+// the constructor's caller nor the accessor's caller can mutate a
+// *QrSegment's state through the slice they hold. This makes a *QrSegment
+// safe to share and read from multiple goroutines concurrently, including
+// passing the same segment slice to more than one EncodeSegments call.
+//
+// The mid-level way to create a segment is to take the payload data and call
+// a factory function such as MakeNumeric. The low-level way to create a
+// segment is to custom-make the bit buffer and call New with appropriate
+// values.
+//
+// This type imposes no length restrictions, but QR Codes have restrictions.
+// Even in the most favorable conditions, a QR Code can only hold 7089
+// characters of data. Any segment longer than this is meaningless for the
+// purpose of generating QR Codes.
+type QrSegment struct {
+	mode     QrSegmentMode
+	numChars int
+	data     []bool
+}
+
+// New creates a new QR Code segment with the given attributes and data.
+// data is copied, so the caller is free to modify or reuse it afterward
+// without affecting the returned segment.
+//
+// The character count (numChars) must agree with the mode and the bit
+// buffer length, but the constraint isn't checked; use Validate or
+// NewChecked if you need that guarantee.
+func New(mode QrSegmentMode, numChars int, data []bool) *QrSegment {
+	if numChars < 0 {
+		panic("numChars out of range")
+	}
+	cp := make([]bool, len(data))
+	copy(cp, data)
+	return &QrSegment{mode: mode, numChars: numChars, data: cp}
+}
+
+// NewChecked is like New, but returns an error instead of panicking when
+// numChars is negative or the data bit length is inconsistent with mode
+// and numChars. Like New, it copies data.
+func NewChecked(mode QrSegmentMode, numChars int, data []bool) (*QrSegment, error) {
+	if numChars < 0 {
+		return nil, fmt.Errorf("qrsegment: numChars out of range: %d", numChars)
+	}
+	cp := make([]bool, len(data))
+	copy(cp, data)
+	seg := &QrSegment{mode: mode, numChars: numChars, data: cp}
+	if err := seg.Validate(); err != nil {
+		return nil, err
+	}
+	return seg, nil
+}
+
+// Validate reports whether this segment's data bit length is consistent
+// with its mode and character count, catching malformed custom segments
+// (typically built via New) before they corrupt a symbol.
+//
+// ECI segments are only checked for their fixed set of valid lengths (one of
+// 8, 16, or 24 bits), since the exact length depends on the assignment value
+// that Validate has no way to recover from the bits alone.
+func (s *QrSegment) Validate() error {
+	n := s.numChars
+	var want int
+	switch s.mode {
+	case Numeric:
+		want = (10*n + 2) / 3 // ceil(10n/3)
+	case Alphanumeric:
+		want = (11*n + 1) / 2 // ceil(11n/2)
+	case Byte:
+		want = 8 * n
+	case Kanji:
+		want = 13 * n
+	case Eci:
+		if n != 0 {
+			return fmt.Errorf("qrsegment: ECI segment must have numChars == 0, got %d", n)
+		}
+		switch len(s.data) {
+		case 8, 16, 24:
+			return nil
+		default:
+			return fmt.Errorf("qrsegment: ECI segment has invalid bit length %d", len(s.data))
+		}
+	case StructuredAppend:
+		if n != 0 {
+			return fmt.Errorf("qrsegment: structured append segment must have numChars == 0, got %d", n)
+		}
+		if len(s.data) != 16 {
+			return fmt.Errorf("qrsegment: structured append segment has invalid bit length %d", len(s.data))
+		}
+		return nil
+	default:
+		return fmt.Errorf("qrsegment: invalid mode %v", s.mode)
+	}
+	if len(s.data) != want {
+		return fmt.Errorf("qrsegment: %v segment with numChars=%d needs %d data bits, got %d", s.mode, n, want, len(s.data))
+	}
+	return nil
+}
+
+// Mode returns the mode indicator of this segment.
+func (s *QrSegment) Mode() QrSegmentMode {
+	return s.mode
+}
+
+// NumChars returns the character count field of this segment.
+func (s *QrSegment) NumChars() int {
+	return s.numChars
+}
+
+// Data returns a copy of the data bits of this segment.
+func (s *QrSegment) Data() []bool {
+	cp := make([]bool, len(s.data))
+	copy(cp, s.data)
+	return cp
+}
+
+// MakeBytes returns a segment representing the given binary data encoded in
+// byte mode. All input byte slices are acceptable, including empty arrays.
+func MakeBytes(data []byte) *QrSegment {
+	bb := make([]bool, 0, len(data)*8)
+	for _, b := range data {
+		bb = appendBits(bb, uint32(b), 8)
+	}
+	return &QrSegment{mode: Byte, numChars: len(data), data: bb}
+}
+
+// MakeNumeric returns a segment representing the given string of decimal
+// digits encoded in numeric mode.
+//
+// Panics if the string contains non-digit characters; use
+// MakeNumericChecked if digits isn't already known to be numeric (e.g. it
+// came from a caller or network request rather than IsNumeric-gated code).
+func MakeNumeric(digits string) *QrSegment {
+	seg, err := MakeNumericChecked(digits)
+	if err != nil {
+		panic(err)
+	}
+	return seg
+}
+
+// MakeNumericChecked is like MakeNumeric, but returns an error instead of
+// panicking if digits contains non-digit characters.
+func MakeNumericChecked(digits string) (*QrSegment, error) {
+	bb := make([]bool, 0, len(digits)*3+(len(digits)+2)/3)
+	var accumData uint32
+	var accumCount int
+	for pos, c := range digits {
+		if c < '0' || c > '9' {
+			return nil, &ErrInvalidCharacter{Pos: pos, Rune: c, Mode: Numeric}
+		}
+		accumData = accumData*10 + uint32(c-'0')
+		accumCount++
+		if accumCount == 3 {
+			bb = appendBits(bb, accumData, 10)
+			accumData = 0
+			accumCount = 0
+		}
+	}
+	if accumCount > 0 { // 1 or 2 digits remaining
+		bb = appendBits(bb, accumData, accumCount*3+1)
+	}
+	return &QrSegment{mode: Numeric, numChars: len(digits), data: bb}, nil
+}
+
+// MakeAlphanumeric returns a segment representing the given text string
+// encoded in alphanumeric mode.
+//
+// The characters allowed are: 0 to 9, A to Z (uppercase only), space,
+// dollar, percent, asterisk, plus, hyphen, period, slash, colon.
+//
+// Panics if the string contains non-encodable characters; use
+// MakeAlphanumericChecked if text isn't already known to be encodable
+// (e.g. it came from a caller or network request rather than
+// IsAlphanumeric-gated code).
+func MakeAlphanumeric(text string) *QrSegment {
+	seg, err := MakeAlphanumericChecked(text)
+	if err != nil {
+		panic(err)
+	}
+	return seg
+}
+
+// MakeAlphanumericChecked is like MakeAlphanumeric, but returns an error
+// instead of panicking if text contains non-encodable characters.
+func MakeAlphanumericChecked(text string) (*QrSegment, error) {
+	bb := make([]bool, 0, len(text)*5+(len(text)+1)/2)
+	var accumData uint32
+	var accumCount int
+	for pos, c := range text {
+		idx := indexInAlphanumericCharset(c)
+		if idx < 0 {
+			return nil, &ErrInvalidCharacter{Pos: pos, Rune: c, Mode: Alphanumeric}
+		}
+		accumData = accumData*45 + uint32(idx)
+		accumCount++
+		if accumCount == 2 {
+			bb = appendBits(bb, accumData, 11)
+			accumData = 0
+			accumCount = 0
+		}
+	}
+	if accumCount > 0 { // 1 character remaining
+		bb = appendBits(bb, accumData, 6)
+	}
+	return &QrSegment{mode: Alphanumeric, numChars: len([]rune(text)), data: bb}, nil
+}
+
+// MakeSegments returns a list of zero or more segments to represent the
+// given Unicode text string.
+//
+// The result is a single segment in numeric, alphanumeric, or byte mode,
+// whichever of those is first able to encode the whole text; modes are not
+// mixed within the text (MakeSegmentsOptimal does that).
+func MakeSegments(text string) []*QrSegment {
+	if text == "" {
+		return nil
+	} else if IsNumeric(text) {
+		return []*QrSegment{MakeNumeric(text)}
+	} else if IsAlphanumeric(text) {
+		return []*QrSegment{MakeAlphanumeric(text)}
+	}
+	return []*QrSegment{MakeBytes([]byte(text))}
+}
+
+// MakeEci returns a segment representing an Extended Channel Interpretation
+// (ECI) designator with the given assignment value.
+//
+// Panics if the assignment value is outside the range [0, 999999]; use
+// MakeEciChecked if assignVal isn't already known to be in range.
+func MakeEci(assignVal uint32) *QrSegment {
+	seg, err := MakeEciChecked(assignVal)
+	if err != nil {
+		panic(err)
+	}
+	return seg
+}
+
+// MakeEciChecked is like MakeEci, but returns an error instead of
+// panicking if assignVal is outside the range [0, 999999].
+func MakeEciChecked(assignVal uint32) (*QrSegment, error) {
+	bb := make([]bool, 0, 24)
+	switch {
+	case assignVal < (1 << 7):
+		bb = appendBits(bb, assignVal, 8)
+	case assignVal < (1 << 14):
+		bb = appendBits(bb, 2, 2)
+		bb = appendBits(bb, assignVal, 14)
+	case assignVal < 1000000:
+		bb = appendBits(bb, 6, 3)
+		bb = appendBits(bb, assignVal, 21)
+	default:
+		return nil, fmt.Errorf("qrsegment: ECI assignment value out of range: %d", assignVal)
+	}
+	return &QrSegment{mode: Eci, numChars: 0, data: bb}, nil
+}
+
+// MakeStructuredAppend returns a segment representing a Structured Append
+// header, which marks a symbol as part index of a sequence of total
+// symbols (1-based count) that together encode one message, along with a
+// parity byte that readers use to confirm the symbols belong together.
+//
+// Per the QR Code Model 2 standard, this segment must be the first segment
+// in the symbol it is used in, total must not exceed 16, and every symbol
+// in the sequence must carry the same total and parity.
+//
+// Panics if total is outside [1, 16], or index is outside [0, total); use
+// MakeStructuredAppendChecked if index and total aren't already known to
+// be in range.
+func MakeStructuredAppend(index, total int, parity byte) *QrSegment {
+	seg, err := MakeStructuredAppendChecked(index, total, parity)
+	if err != nil {
+		panic(err)
+	}
+	return seg
+}
+
+// MakeStructuredAppendChecked is like MakeStructuredAppend, but returns
+// an error instead of panicking if total is outside [1, 16], or index is
+// outside [0, total).
+func MakeStructuredAppendChecked(index, total int, parity byte) (*QrSegment, error) {
+	if total < 1 || total > 16 || index < 0 || index >= total {
+		return nil, fmt.Errorf("qrsegment: structured append index/total out of range: index=%d, total=%d", index, total)
+	}
+	bb := make([]bool, 0, 16)
+	bb = appendBits(bb, uint32(index), 4)
+	bb = appendBits(bb, uint32(total-1), 4)
+	bb = appendBits(bb, uint32(parity), 8)
+	return &QrSegment{mode: StructuredAppend, numChars: 0, data: bb}, nil
+}
+
+// GetTotalBits calculates and returns the number of bits needed to encode
+// the given segments at the given version. The second return value is false
+// if a segment has too many characters to fit its length field, or the
+// total bits would overflow.
+func GetTotalBits(segs []*QrSegment, version Version) (int, bool) {
+	result := 0
+	for _, seg := range segs {
+		n, ok := seg.TotalBitsAt(version)
+		if !ok {
+			return 0, false
+		}
+		sum := result + n
+		if sum < result {
+			return 0, false // Overflow
+		}
+		result = sum
+	}
+	return result, true
+}
+
+// TotalBitsAt returns the number of bits this segment alone would occupy
+// (mode indicator, character count field, and data) if placed in a QR Code
+// of the given version, along with whether it fits the character count
+// field's bit width at all.
+//
+// This lets planning tools compare the cost of individual segments at
+// candidate versions without building a throwaway slice just to call the
+// package-level GetTotalBits.
+func (s *QrSegment) TotalBitsAt(ver Version) (int, bool) {
+	ccbits := s.mode.NumCharCountBits(ver)
+	if ccbits < 31 && s.numChars >= (1<<uint(ccbits)) {
+		return 0, false // The segment's length doesn't fit the field's bit width
+	}
+	return 4 + ccbits + len(s.data), true
+}
+
+// BitCost returns the number of bits text would occupy as a single segment
+// in the given mode at version ver (mode indicator, character count field,
+// and data), without building the segment's full bit buffer. This lets
+// segmentation strategies and UIs compare candidate modes' costs for a
+// string before committing to one.
+//
+// mode must be Numeric, Alphanumeric, Byte, or Kanji; BitCost returns an
+// error for a control mode (Eci or StructuredAppend), since those carry no
+// string payload of their own to cost. Returns an error if text contains a
+// character mode cannot encode, or if its length doesn't fit the character
+// count field at ver.
+func BitCost(text string, mode QrSegmentMode, ver Version) (int, error) {
+	var numChars, dataBits int
+	switch mode {
+	case Numeric:
+		numChars = len(text)
+		for pos, c := range text {
+			if c < '0' || c > '9' {
+				return 0, &ErrInvalidCharacter{Pos: pos, Rune: c, Mode: Numeric}
+			}
+		}
+		dataBits = 10*(numChars/3) + [3]int{0, 4, 7}[numChars%3]
+	case Alphanumeric:
+		for pos, c := range text {
+			if indexInAlphanumericCharset(c) < 0 {
+				return 0, &ErrInvalidCharacter{Pos: pos, Rune: c, Mode: Alphanumeric}
+			}
+			numChars++
+		}
+		dataBits = 11*(numChars/2) + 6*(numChars%2)
+	case Byte:
+		numChars = len(text)
+		dataBits = 8 * numChars
+	case Kanji:
+		for pos, c := range text {
+			if _, ok := runeToKanjiValue(c); !ok {
+				return 0, &ErrInvalidCharacter{Pos: pos, Rune: c, Mode: Kanji}
+			}
+			numChars++
+		}
+		dataBits = 13 * numChars
+	default:
+		return 0, fmt.Errorf("qrsegment: BitCost does not apply to control mode %v", mode)
+	}
+
+	ccbits := mode.NumCharCountBits(ver)
+	if ccbits < 31 && numChars >= (1<<uint(ccbits)) {
+		return 0, fmt.Errorf("qrsegment: %d characters doesn't fit %v mode's character count field at version %d", numChars, mode, ver)
+	}
+	return 4 + ccbits + dataBits, nil
+}
+
+// IsNumeric tests whether the given string can be encoded as a segment in
+// numeric mode. A string is encodable iff each character is in the range
+// 0 to 9.
+func IsNumeric(text string) bool {
+	for _, c := range text {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAlphanumeric tests whether the given string can be encoded as a segment
+// in alphanumeric mode. A string is encodable iff each character is in the
+// following set: 0 to 9, A to Z (uppercase only), space, dollar, percent,
+// asterisk, plus, hyphen, period, slash, colon.
+func IsAlphanumeric(text string) bool {
+	for _, c := range text {
+		if indexInAlphanumericCharset(c) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckNumeric is like IsNumeric, but returns an *ErrInvalidCharacter
+// identifying the first non-digit character's byte offset and rune instead
+// of a plain bool, e.g. for a form-validation UI to highlight exactly what
+// to fix. Returns nil if text is numeric.
+func CheckNumeric(text string) error {
+	for pos, c := range text {
+		if c < '0' || c > '9' {
+			return &ErrInvalidCharacter{Pos: pos, Rune: c, Mode: Numeric}
+		}
+	}
+	return nil
+}
+
+// CheckAlphanumeric is like IsAlphanumeric, but returns an
+// *ErrInvalidCharacter identifying the first non-encodable character's byte
+// offset and rune instead of a plain bool. Returns nil if text is
+// alphanumeric.
+func CheckAlphanumeric(text string) error {
+	for pos, c := range text {
+		if indexInAlphanumericCharset(c) < 0 {
+			return &ErrInvalidCharacter{Pos: pos, Rune: c, Mode: Alphanumeric}
+		}
+	}
+	return nil
+}
+
+func indexInAlphanumericCharset(c rune) int {
+	for i, x := range alphanumericCharset {
+		if x == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// appendBits appends the given number of low-order bits of the given value
+// to bb, returning the extended slice. Requires len <= 31 and val < 2^len.
+func appendBits(bb []bool, val uint32, length int) []bool {
+	if length < 0 || length > 31 || (val>>uint(length)) != 0 {
+		panic("value out of range")
+	}
+	for i := length - 1; i >= 0; i-- {
+		bb = append(bb, (val>>uint(i))&1 != 0)
+	}
+	return bb
+}
@@ -0,0 +1,108 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Package qrtemplate provides a FuncMap of QR Code generation functions for
+// use with html/template, so a web app can embed a code directly in a page
+// template without writing its own handler plumbing.
+package qrtemplate
+
+import (
+	"fmt"
+	"html/template"
+
+	"github.com/nayuki/qrcodegen"
+	"github.com/nayuki/qrcodegen/render"
+)
+
+// FuncMap returns the template functions this package provides, ready to
+// pass to (*html/template.Template).Funcs:
+//
+//   - qrSVG text ecl border: renders text as an SVG image (at the named
+//     error correction level, with the given number of border modules) and
+//     returns it as template.HTML, safe to emit unescaped inside a
+//     template so the <svg> markup is inlined directly into the page.
+//   - qrDataURI text ecl format scale border: renders text in the named
+//     format ("svg" or "png") and returns it as a "data:" URI of type
+//     template.URL, safe to emit unescaped into an img src attribute.
+//
+// ecl names one of the four error correction levels by its first letter:
+// "L", "M", "Q", or "H".
+//
+// Both functions return an error as their second result (per the usual
+// html/template convention) if text does not fit any QR Code version, or
+// if ecl or format name an unrecognized value; template execution then
+// fails with that error.
+//
+// The returned map's underlying type is map[string]any, so it can also be
+// passed to (*text/template.Template).Funcs via a conversion,
+// texttemplate.FuncMap(qrtemplate.FuncMap()), for callers who want the raw
+// markup or URI without html/template's escaping guarantees.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"qrSVG":     qrSVG,
+		"qrDataURI": qrDataURI,
+	}
+}
+
+func qrSVG(text, ecl string, border int) (template.HTML, error) {
+	level, err := parseEcl(ecl)
+	if err != nil {
+		return "", err
+	}
+	qr, err := qrcodegen.EncodeText(text, level)
+	if err != nil {
+		return "", err
+	}
+	svg, err := qr.ToSvgStringChecked(int32(border))
+	if err != nil {
+		return "", err
+	}
+	return template.HTML(svg), nil
+}
+
+func qrDataURI(text, ecl, format string, scale, border int) (template.URL, error) {
+	level, err := parseEcl(ecl)
+	if err != nil {
+		return "", err
+	}
+	var f render.Format
+	switch format {
+	case "svg":
+		f = render.FormatSVG
+	case "png":
+		f = render.FormatPNG
+	default:
+		return "", fmt.Errorf("qrtemplate: unrecognized format %q", format)
+	}
+	qr, err := qrcodegen.EncodeText(text, level)
+	if err != nil {
+		return "", err
+	}
+	uri, err := render.ToDataURI(qr, f, render.DataURIOptions{
+		Border: int32(border),
+		Scale:  int32(scale),
+	})
+	if err != nil {
+		return "", err
+	}
+	return template.URL(uri), nil
+}
+
+func parseEcl(s string) (qrcodegen.QrCodeEcc, error) {
+	switch s {
+	case "L":
+		return qrcodegen.Low, nil
+	case "M":
+		return qrcodegen.Medium, nil
+	case "Q":
+		return qrcodegen.Quartile, nil
+	case "H":
+		return qrcodegen.High, nil
+	default:
+		return 0, fmt.Errorf("qrtemplate: unrecognized ecl %q", s)
+	}
+}
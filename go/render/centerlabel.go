@@ -0,0 +1,162 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// CenterLabelOptions describes a short text label drawn in a reserved
+// square at a symbol's center, commonly used for a human-readable asset ID
+// or serial number printed underneath the scan region.
+type CenterLabelOptions struct {
+	// Text is the label to draw. SVGWithCenterLabel is a no-op if this is
+	// empty.
+	Text string
+
+	// FontSize is the text height, in the same units as the surrounding
+	// SVG viewBox (i.e. modules). If zero, it defaults to 60% of the
+	// reserved square's side.
+	FontSize float64
+
+	// TextColor and BackgroundColor are SVG color values (e.g.
+	// "#000000") for the label text and the square painted behind it.
+	// Empty defaults to black text on a white background.
+	TextColor, BackgroundColor string
+}
+
+// MaxCenterLabelModules returns the largest odd side length, in modules, of
+// a square that can be reserved at qr's center for a CenterLabelOptions
+// overlay without risking more visual damage than qr's error correction
+// level can be expected to recover from: the label's area divided by qr's
+// total module count is kept within the same eccRecoveryFraction budget
+// CheckScannability uses for LogoCoverage. Returns 0 if even a single
+// module's worth of budget isn't available.
+//
+// The side is kept odd so the square centers exactly on the module grid.
+func MaxCenterLabelModules(qr *qrcodegen.QrCode) int32 {
+	size := qr.Size()
+	budget := eccRecoveryFraction[qr.ErrorCorrectionLevel()]
+	side := int32(math.Sqrt(budget * float64(size) * float64(size)))
+	if side > size {
+		side = size
+	}
+	if side%2 == 0 {
+		side--
+	}
+	if side < 1 {
+		return 0
+	}
+	return side
+}
+
+// SVGWithCenterLabel returns the same SVG that qr.ToSvgStringWithOptions
+// would produce with svgOpts, but with label's background and text drawn
+// over a square reserved at the symbol's center, sized by
+// MaxCenterLabelModules.
+//
+// If label.Text is empty, this is equivalent to
+// qr.ToSvgStringWithOptionsChecked(border, svgOpts). Otherwise, returns an
+// error if border is negative, or if MaxCenterLabelModules(qr) is 0 (qr's
+// error correction level leaves no safe room for a label).
+func SVGWithCenterLabel(qr *qrcodegen.QrCode, border int32, svgOpts qrcodegen.SvgOptions, label CenterLabelOptions) (string, error) {
+	svg, err := qr.ToSvgStringWithOptionsChecked(border, svgOpts)
+	if err != nil {
+		return "", err
+	}
+	if label.Text == "" {
+		return svg, nil
+	}
+
+	side := MaxCenterLabelModules(qr)
+	if side == 0 {
+		return "", fmt.Errorf("render: %v error correction leaves no safe room for a center label", qr.ErrorCorrectionLevel())
+	}
+
+	dimension := float64(qr.Size() + border*2)
+	center := dimension / 2
+	half := float64(side) / 2
+
+	bg := label.BackgroundColor
+	if bg == "" {
+		bg = "#FFFFFF"
+	}
+	fg := label.TextColor
+	if fg == "" {
+		fg = "#000000"
+	}
+	fontSize := label.FontSize
+	if fontSize <= 0 {
+		fontSize = float64(side) * 0.6
+	}
+
+	overlay := fmt.Sprintf(
+		"\t<rect x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\" fill=\"%s\"/>\n"+
+			"\t<text x=\"%s\" y=\"%s\" text-anchor=\"middle\" dominant-baseline=\"middle\" font-size=\"%s\" fill=\"%s\">%s</text>\n",
+		fmtNum(center-half), fmtNum(center-half), fmtNum(float64(side)), fmtNum(float64(side)), xmlEscapeAttr(bg),
+		fmtNum(center), fmtNum(center), fmtNum(fontSize), xmlEscapeAttr(fg), xmlEscapeText(label.Text))
+
+	return insertBeforeClosingSvgTag(svg, overlay), nil
+}
+
+// insertBeforeClosingSvgTag splices fragment in just before svg's closing
+// "</svg>\n", so it draws on top of everything already in the document.
+func insertBeforeClosingSvgTag(svg, fragment string) string {
+	const closing = "</svg>\n"
+	if strings.HasSuffix(svg, closing) {
+		return svg[:len(svg)-len(closing)] + fragment + closing
+	}
+	return svg + fragment
+}
+
+// xmlEscapeText escapes the characters that are special inside SVG/XML
+// text content.
+func xmlEscapeText(s string) string {
+	var buf strings.Builder
+	for _, r := range s {
+		switch r {
+		case '&':
+			buf.WriteString("&amp;")
+		case '<':
+			buf.WriteString("&lt;")
+		case '>':
+			buf.WriteString("&gt;")
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
+
+// xmlEscapeAttr escapes the characters that are special inside a
+// double-quoted SVG/XML attribute value, for any caller-supplied string
+// (e.g. a color) interpolated into one: unlike xmlEscapeText, this also
+// escapes the double quote itself, since an unescaped one lets the value
+// break out of the attribute and inject arbitrary markup.
+func xmlEscapeAttr(s string) string {
+	var buf strings.Builder
+	for _, r := range s {
+		switch r {
+		case '&':
+			buf.WriteString("&amp;")
+		case '<':
+			buf.WriteString("&lt;")
+		case '>':
+			buf.WriteString("&gt;")
+		case '"':
+			buf.WriteString("&quot;")
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
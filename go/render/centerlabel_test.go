@@ -0,0 +1,41 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// TestSVGWithCenterLabelAttributeInjection checks that TextColor and
+// BackgroundColor values containing a double quote can't break out of the
+// fill attributes they're interpolated into and inject arbitrary SVG
+// markup or event handlers.
+func TestSVGWithCenterLabelAttributeInjection(t *testing.T) {
+	qr, err := qrcodegen.EncodeText("attribute injection test", qrcodegen.Low)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const payload = `red" onload="alert(1)" x="`
+	svg, err := SVGWithCenterLabel(qr, 4, qrcodegen.SvgOptions{}, CenterLabelOptions{
+		Text:            "ID",
+		TextColor:       payload,
+		BackgroundColor: payload,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(svg, payload) {
+		t.Errorf("SVGWithCenterLabel did not escape TextColor/BackgroundColor; output contains the raw, unescaped payload: %q", svg)
+	}
+	if !strings.Contains(svg, "&quot;") {
+		t.Errorf("SVGWithCenterLabel output has no escaped quote, so the payload's embedded \" was not neutralized: %q", svg)
+	}
+}
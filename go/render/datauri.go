@@ -0,0 +1,52 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// Format selects the image format ToDataURI encodes qr into.
+type Format int
+
+const (
+	FormatSVG Format = iota
+	FormatPNG
+)
+
+// DataURIOptions controls the image produced by ToDataURI. Border applies
+// to both formats; Scale and PNG apply only to FormatPNG, and SVG applies
+// only to FormatSVG.
+type DataURIOptions struct {
+	Border int32
+	Scale  int32
+	SVG    qrcodegen.SvgOptions
+	PNG    PNGOptions
+}
+
+// ToDataURI renders qr in the given format and returns it as a "data:" URI
+// with base64-encoded content, ready for direct use in an HTML img src
+// attribute or an email body.
+func ToDataURI(qr *qrcodegen.QrCode, format Format, opts DataURIOptions) (string, error) {
+	switch format {
+	case FormatSVG:
+		svg := qr.ToSvgStringWithOptions(opts.Border, opts.SVG)
+		return "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(svg)), nil
+	case FormatPNG:
+		b, err := ToPNG(qr, opts.Scale, opts.Border, opts.PNG)
+		if err != nil {
+			return "", err
+		}
+		return "data:image/png;base64," + base64.StdEncoding.EncodeToString(b), nil
+	default:
+		return "", fmt.Errorf("render: unknown format %v", format)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// DrawOptions controls the colors Draw paints with, beyond the symbol's own
+// size and border.
+type DrawOptions struct {
+	// Light is the color painted for light modules and the border. A nil
+	// Light paints color.White.
+	Light color.Color
+
+	// Dark is the color painted for dark modules. A nil Dark paints
+	// color.Black.
+	Dark color.Color
+}
+
+// drawRenderer is a ModuleRenderer that paints each module as a
+// scale-by-scale block of pixels directly into dst, offset by origin,
+// without the intermediate *image.Paletted that palettedRenderer builds.
+type drawRenderer struct {
+	dst         draw.Image
+	origin      image.Point
+	scale       int32
+	light, dark color.Color
+}
+
+func (d *drawRenderer) Module(x, y int32, dark, isFunction bool) {
+	c := d.light
+	if dark {
+		c = d.dark
+	}
+	baseX := d.origin.X + int(x*d.scale)
+	baseY := d.origin.Y + int(y*d.scale)
+	for dy := 0; dy < int(d.scale); dy++ {
+		for dx := 0; dx < int(d.scale); dx++ {
+			d.dst.Set(baseX+dx, baseY+dy, c)
+		}
+	}
+}
+
+// Draw paints qr directly into dst within r, with each module occupying a
+// scale-by-scale block of pixels and a border modules wide of quiet zone on
+// every side, without allocating an intermediate image. This lets a caller
+// composite a symbol onto an existing canvas, such as a poster or ID card,
+// in place.
+//
+// r must be exactly as large as the rendered symbol (size+2*border, scaled);
+// Draw returns an error rather than clipping or centering a mismatched r, so
+// a caller's layout mistake is reported instead of producing a silently
+// misaligned symbol. Use rasterDimension's math, exposed here as
+// DrawnSize, to size r correctly.
+//
+// Returns an error if scale <= 0, border < 0, or r's dimensions don't match
+// the rendered size exactly.
+func Draw(dst draw.Image, r image.Rectangle, qr *qrcodegen.QrCode, scale, border int32, opts DrawOptions) error {
+	if err := validateScaleBorder(scale, border); err != nil {
+		return err
+	}
+	dim, err := rasterDimension(qr.Size(), border, scale)
+	if err != nil {
+		return err
+	}
+	if r.Dx() != dim || r.Dy() != dim {
+		return fmt.Errorf("render: region %v is %dx%d, want %dx%d", r, r.Dx(), r.Dy(), dim, dim)
+	}
+
+	light := opts.Light
+	if light == nil {
+		light = color.White
+	}
+	dark := opts.Dark
+	if dark == nil {
+		dark = color.Black
+	}
+
+	Drive(qr, border, &drawRenderer{dst: dst, origin: r.Min, scale: scale, light: light, dark: dark})
+	return nil
+}
+
+// DrawnSize returns the pixel width/height that Draw requires r to be for
+// the given symbol size, border, and scale, for a caller computing its
+// layout before allocating or slicing an image. It is rasterDimension
+// exposed under a name meaningful to Draw's callers.
+func DrawnSize(size, border, scale int32) (int, error) {
+	return rasterDimension(size, border, scale)
+}
@@ -0,0 +1,78 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// ToDXF renders qr as a minimal R12-compatible DXF document: one closed
+// POLYLINE entity per horizontal run of dark modules, merging horizontally
+// adjacent dark modules in the same row into a single rectangle, the same
+// way the PDF and SVG renderers do. This lets laser engravers and CAD
+// software import the symbol natively, at exact dimensions, as
+// cuttable/millable outlines rather than a raster image.
+//
+// Coordinates are in moduleSizeMM millimeters per module. DXF's Y axis
+// increases upward, the opposite of the row-major order QrCode.GetModule
+// uses, so rows are flipped to keep the drawing right-side up when opened
+// in CAD software.
+//
+// Returns an error if border is negative or moduleSizeMM is not positive.
+func ToDXF(qr *qrcodegen.QrCode, border int32, moduleSizeMM float64) ([]byte, error) {
+	if border < 0 {
+		return nil, fmt.Errorf("render: border must be non-negative, got %d", border)
+	}
+	if moduleSizeMM <= 0 {
+		return nil, fmt.Errorf("render: moduleSizeMM must be positive, got %g", moduleSizeMM)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("0\nSECTION\n2\nENTITIES\n")
+
+	size := qr.Size()
+	for y := int32(0); y < size; y++ {
+		for x := int32(0); x < size; {
+			if !qr.GetModule(x, y) {
+				x++
+				continue
+			}
+			runStart := x
+			for x < size && qr.GetModule(x, y) {
+				x++
+			}
+			writeDxfRect(&buf,
+				float64(runStart+border)*moduleSizeMM, float64(y+border)*moduleSizeMM,
+				float64(x-runStart)*moduleSizeMM, moduleSizeMM)
+		}
+	}
+
+	buf.WriteString("0\nENDSEC\n0\nEOF\n")
+	return buf.Bytes(), nil
+}
+
+// writeDxfRect appends a closed 4-vertex POLYLINE entity for the rectangle
+// of width w and height h whose top-left corner (in row-major, Y-down
+// terms) is at (x, y), flipping Y so the drawing reads right-side up in
+// DXF's Y-up coordinate system.
+func writeDxfRect(buf *bytes.Buffer, x, y, w, h float64) {
+	buf.WriteString("0\nPOLYLINE\n8\n0\n66\n1\n70\n1\n")
+	corners := [4][2]float64{
+		{x, -y},
+		{x + w, -y},
+		{x + w, -(y + h)},
+		{x, -(y + h)},
+	}
+	for _, c := range corners {
+		fmt.Fprintf(buf, "0\nVERTEX\n8\n0\n10\n%s\n20\n%s\n", fmtNum(c[0]), fmtNum(c[1]))
+	}
+	buf.WriteString("0\nSEQEND\n")
+}
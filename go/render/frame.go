@@ -0,0 +1,151 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// FrameOptions describes a decorative rounded border and/or call-to-action
+// banner drawn around a symbol's existing quiet zone, for a marketing flyer
+// or poster that would otherwise need this composited on in a separate
+// design tool.
+//
+// The zero value draws neither: SVGWithFrame is a no-op unless BannerText
+// is non-empty or BorderWidth is positive.
+type FrameOptions struct {
+	// BorderWidth is the stroke width of a rounded rectangle drawn just
+	// outside the symbol's own quiet zone, in the same units as the
+	// surrounding SVG viewBox (i.e. modules). Zero omits the border.
+	BorderWidth float64
+
+	// CornerRadius is the border's corner radius, in the same units.
+	CornerRadius float64
+
+	// BorderColor is the border's SVG color value. Empty defaults to
+	// "#000000".
+	BorderColor string
+
+	// BannerText is a short call-to-action (e.g. "Scan me") drawn in a
+	// solid-color strip appended below the symbol. Empty omits the banner.
+	BannerText string
+
+	// BannerHeight is the strip's height, in modules. If BannerText is
+	// non-empty and this is zero, it defaults to 2.5.
+	BannerHeight float64
+
+	// BannerFontSize is the banner text's height, in modules. If zero, it
+	// defaults to 60% of BannerHeight.
+	BannerFontSize float64
+
+	// BannerColor and BannerTextColor are SVG color values for the strip's
+	// background and its text. Empty defaults to black background with
+	// white text.
+	BannerColor, BannerTextColor string
+}
+
+// SVGWithFrame returns the same SVG that qr.ToSvgStringWithOptions would
+// produce with svgOpts, enlarged to fit frame's rounded border and/or
+// banner drawn around it.
+//
+// Returns an error if border is negative, frame.BorderWidth is negative, or
+// the symbol's own SVG is malformed (which should not happen for any SVG
+// this package produced itself).
+func SVGWithFrame(qr *qrcodegen.QrCode, border int32, svgOpts qrcodegen.SvgOptions, frame FrameOptions) (string, error) {
+	svg, err := qr.ToSvgStringWithOptionsChecked(border, svgOpts)
+	if err != nil {
+		return "", err
+	}
+	if frame.BorderWidth < 0 {
+		return "", fmt.Errorf("render: BorderWidth must be non-negative, got %g", frame.BorderWidth)
+	}
+	if frame.BannerText == "" && frame.BorderWidth <= 0 {
+		return svg, nil
+	}
+
+	content, err := extractSvgContent(svg)
+	if err != nil {
+		return "", err
+	}
+
+	bannerHeight := frame.BannerHeight
+	if frame.BannerText != "" && bannerHeight <= 0 {
+		bannerHeight = 2.5
+	} else if frame.BannerText == "" {
+		bannerHeight = 0
+	}
+	fontSize := frame.BannerFontSize
+	if fontSize <= 0 {
+		fontSize = bannerHeight * 0.6
+	}
+	borderColor := frame.BorderColor
+	if borderColor == "" {
+		borderColor = "#000000"
+	}
+	bannerColor := frame.BannerColor
+	if bannerColor == "" {
+		bannerColor = "#000000"
+	}
+	bannerTextColor := frame.BannerTextColor
+	if bannerTextColor == "" {
+		bannerTextColor = "#FFFFFF"
+	}
+
+	innerDim := float64(qr.Size() + border*2)
+	offset := frame.BorderWidth
+	boxSide := innerDim + 2*frame.BorderWidth
+	width := boxSide
+	height := boxSide + bannerHeight
+
+	var out strings.Builder
+	if !svgOpts.Fragment {
+		out.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+		out.WriteString("<!DOCTYPE svg PUBLIC \"-//W3C//DTD SVG 1.1//EN\" \"http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd\">\n")
+	}
+	fmt.Fprintf(&out, "<svg xmlns=\"http://www.w3.org/2000/svg\" version=\"1.1\" viewBox=\"0 0 %s %s\" stroke=\"none\">\n",
+		fmtNum(width), fmtNum(height))
+	out.WriteString("\t<rect width=\"100%\" height=\"100%\" fill=\"#FFFFFF\"/>\n")
+	fmt.Fprintf(&out, "\t<g transform=\"translate(%s,%s)\">\n%s\t</g>\n", fmtNum(offset), fmtNum(offset), content)
+	if frame.BorderWidth > 0 {
+		fmt.Fprintf(&out, "\t<rect x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\" rx=\"%s\" ry=\"%s\" fill=\"none\" stroke=\"%s\" stroke-width=\"%s\"/>\n",
+			fmtNum(offset/2), fmtNum(offset/2), fmtNum(innerDim+frame.BorderWidth), fmtNum(innerDim+frame.BorderWidth), fmtNum(frame.CornerRadius), fmtNum(frame.CornerRadius),
+			xmlEscapeAttr(borderColor), fmtNum(frame.BorderWidth))
+	}
+	if frame.BannerText != "" {
+		fmt.Fprintf(&out, "\t<rect x=\"0\" y=\"%s\" width=\"%s\" height=\"%s\" fill=\"%s\"/>\n",
+			fmtNum(boxSide), fmtNum(width), fmtNum(bannerHeight), xmlEscapeAttr(bannerColor))
+		fmt.Fprintf(&out, "\t<text x=\"%s\" y=\"%s\" text-anchor=\"middle\" dominant-baseline=\"middle\" font-size=\"%s\" fill=\"%s\">%s</text>\n",
+			fmtNum(width/2), fmtNum(boxSide+bannerHeight/2), fmtNum(fontSize), xmlEscapeAttr(bannerTextColor), xmlEscapeText(frame.BannerText))
+	}
+	out.WriteString("</svg>\n")
+	return out.String(), nil
+}
+
+// extractSvgContent returns everything between svg's opening "<svg ...>"
+// tag and its closing "</svg>\n", for SVGWithFrame to re-wrap inside a
+// translated group in a larger canvas.
+func extractSvgContent(svg string) (string, error) {
+	start := strings.Index(svg, "<svg")
+	if start < 0 {
+		return "", errors.New("render: malformed SVG: no <svg> tag found")
+	}
+	tagEnd := strings.Index(svg[start:], ">")
+	if tagEnd < 0 {
+		return "", errors.New("render: malformed SVG: unterminated <svg> tag")
+	}
+	const closing = "</svg>\n"
+	if !strings.HasSuffix(svg, closing) {
+		return "", errors.New("render: malformed SVG: missing closing </svg> tag")
+	}
+	contentStart := start + tagEnd + 1
+	return svg[contentStart : len(svg)-len(closing)], nil
+}
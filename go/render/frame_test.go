@@ -0,0 +1,43 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// TestSVGWithFrameAttributeInjection checks that BorderColor, BannerColor,
+// and BannerTextColor values containing a double quote can't break out of
+// the attributes they're interpolated into and inject arbitrary SVG markup
+// or event handlers.
+func TestSVGWithFrameAttributeInjection(t *testing.T) {
+	qr, err := qrcodegen.EncodeText("attribute injection test", qrcodegen.Low)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const payload = `red" onload="alert(1)" x="`
+	svg, err := SVGWithFrame(qr, 4, qrcodegen.SvgOptions{}, FrameOptions{
+		BorderWidth:     1,
+		BorderColor:     payload,
+		BannerText:      "Scan me",
+		BannerColor:     payload,
+		BannerTextColor: payload,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(svg, payload) {
+		t.Errorf("SVGWithFrame did not escape BorderColor/BannerColor/BannerTextColor; output contains the raw, unescaped payload: %q", svg)
+	}
+	if !strings.Contains(svg, "&quot;") {
+		t.Errorf("SVGWithFrame output has no escaped quote, so the payload's embedded \" was not neutralized: %q", svg)
+	}
+}
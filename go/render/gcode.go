@@ -0,0 +1,88 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// GCodeOptions controls ToGCode's module size, feed rate, and laser power.
+type GCodeOptions struct {
+	// ModuleSizeMM is the side length of one module, in millimeters.
+	ModuleSizeMM float64
+
+	// FeedRate is the cutting/marking speed, in millimeters per minute.
+	FeedRate float64
+
+	// LaserPower is the spindle/laser power command value (e.g. 0-1000 for
+	// GRBL's S parameter), sent once at the start of the job.
+	LaserPower float64
+}
+
+// ToGCode renders qr as G-code tracing the outline of each dark module,
+// merging horizontally adjacent dark modules in the same row into a single
+// rectangular toolpath, for a laser cutter/engraver or CNC router to mark
+// the symbol directly rather than from a raster image traced by separate
+// software.
+//
+// The emitted dialect follows common GRBL conventions: G21 (millimeters),
+// G90 (absolute positioning), M3 (laser/spindle on) with an S parameter at
+// the start of the job, M5 (off) at the end, G0 rapid moves between shapes,
+// and G1 feed moves (at opts.FeedRate) tracing each shape's outline.
+//
+// Returns an error if border is negative, or opts.ModuleSizeMM or
+// opts.FeedRate is not positive.
+func ToGCode(qr *qrcodegen.QrCode, border int32, opts GCodeOptions) ([]byte, error) {
+	if border < 0 {
+		return nil, fmt.Errorf("render: border must be non-negative, got %d", border)
+	}
+	if opts.ModuleSizeMM <= 0 {
+		return nil, fmt.Errorf("render: ModuleSizeMM must be positive, got %g", opts.ModuleSizeMM)
+	}
+	if opts.FeedRate <= 0 {
+		return nil, fmt.Errorf("render: FeedRate must be positive, got %g", opts.FeedRate)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("G21 ; millimeters\nG90 ; absolute positioning\n")
+	fmt.Fprintf(&buf, "M3 S%s ; laser on\n", fmtNum(opts.LaserPower))
+
+	size := qr.Size()
+	for y := int32(0); y < size; y++ {
+		for x := int32(0); x < size; {
+			if !qr.GetModule(x, y) {
+				x++
+				continue
+			}
+			runStart := x
+			for x < size && qr.GetModule(x, y) {
+				x++
+			}
+			writeGCodeRect(&buf,
+				float64(runStart+border)*opts.ModuleSizeMM, float64(y+border)*opts.ModuleSizeMM,
+				float64(x-runStart)*opts.ModuleSizeMM, opts.ModuleSizeMM, opts.FeedRate)
+		}
+	}
+
+	buf.WriteString("M5 ; laser off\n")
+	return buf.Bytes(), nil
+}
+
+// writeGCodeRect appends a rapid move to the rectangle's start corner
+// followed by feed moves tracing its perimeter back to the start, for the
+// rectangle of width w and height h with top-left corner at (x, y).
+func writeGCodeRect(buf *bytes.Buffer, x, y, w, h, feedRate float64) {
+	fmt.Fprintf(buf, "G0 X%s Y%s\n", fmtNum(x), fmtNum(y))
+	fmt.Fprintf(buf, "G1 X%s Y%s F%s\n", fmtNum(x+w), fmtNum(y), fmtNum(feedRate))
+	fmt.Fprintf(buf, "G1 X%s Y%s\n", fmtNum(x+w), fmtNum(y+h))
+	fmt.Fprintf(buf, "G1 X%s Y%s\n", fmtNum(x), fmtNum(y+h))
+	fmt.Fprintf(buf, "G1 X%s Y%s\n", fmtNum(x), fmtNum(y))
+}
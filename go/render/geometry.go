@@ -0,0 +1,68 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import "github.com/nayuki/qrcodegen"
+
+// CameraOptics describes the imaging side of a camera-based scanner, for
+// MinModuleSizeMM to estimate the smallest module a given camera can
+// resolve at a given distance.
+type CameraOptics struct {
+	// FocalLengthMM is the lens focal length.
+	FocalLengthMM float64
+
+	// PixelPitchMM is the sensor's pixel size (the distance between the
+	// centers of two adjacent pixels).
+	PixelPitchMM float64
+}
+
+// minPixelsPerModule is the number of sensor pixels ISO/IEC 15415 and
+// 15426-2 recommend falling across a symbol's narrowest element (one
+// module) for a reliable decode; below this, modules blur into their
+// neighbors before the decoder can tell them apart.
+const minPixelsPerModule = 3
+
+// SymbolWidthMM returns the total physical width of qr as printed, in
+// millimeters: the symbol itself plus a quiet zone of border modules on
+// each side. QR Code symbols are square, so this is also the height.
+func SymbolWidthMM(qr *qrcodegen.QrCode, border int32, moduleSizeMM float64) float64 {
+	return float64(qr.Size()+2*border) * moduleSizeMM
+}
+
+// MinModuleSizeMM estimates the smallest module size, in millimeters, that
+// optics can reliably resolve at scanDistanceMM away, using the standard
+// pinhole projection of one sensor pixel onto the object plane and the
+// ISO/IEC 15415/15426-2 guidance of at least minPixelsPerModule pixels
+// across each module.
+//
+// Panics if optics.FocalLengthMM is not positive.
+func MinModuleSizeMM(scanDistanceMM float64, optics CameraOptics) float64 {
+	if optics.FocalLengthMM <= 0 {
+		panic("FocalLengthMM must be positive")
+	}
+	pixelFootprintMM := scanDistanceMM * optics.PixelPitchMM / optics.FocalLengthMM
+	return pixelFootprintMM * minPixelsPerModule
+}
+
+// MaxVersionForArea returns the largest version whose printed symbol, at
+// moduleSizeMM per module plus a quiet zone of border modules on each side,
+// fits within a square printable area areaWidthMM on a side. The second
+// return value is false if even qrcodegen.MinVersion does not fit.
+func MaxVersionForArea(areaWidthMM float64, border int32, moduleSizeMM float64) (qrcodegen.Version, bool) {
+	best := qrcodegen.MinVersion
+	found := false
+	for ver := qrcodegen.MinVersion; ver <= qrcodegen.MaxVersion; ver++ {
+		size := float64(int32(ver)*4+17+2*border) * moduleSizeMM
+		if size > areaWidthMM {
+			break
+		}
+		best = ver
+		found = true
+	}
+	return best, found
+}
@@ -0,0 +1,59 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"image/gif"
+	"time"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// ToAnimatedGIF renders a sequence of QR Codes (such as the symbols
+// produced by EncodeTextAcross, or the 8 mask variants from
+// EncodeCodewordsAllMasks) as a looping animated GIF, one symbol per frame,
+// each shown for frameDelay before advancing.
+//
+// Every symbol is rasterized independently at the given scale and border.
+//
+// Returns an error if codes is empty, scale <= 0, border < 0, the
+// resulting image would exceed maxRasterDimension pixels per side, or
+// frameDelay is too short to represent in the GIF format's
+// hundredths-of-a-second delay field.
+func ToAnimatedGIF(codes []*qrcodegen.QrCode, scale, border int32, frameDelay time.Duration) ([]byte, error) {
+	if len(codes) == 0 {
+		return nil, errors.New("render: no QR Codes given")
+	}
+	if err := validateScaleBorder(scale, border); err != nil {
+		return nil, err
+	}
+	delayHundredths := int(frameDelay / (10 * time.Millisecond))
+	if delayHundredths <= 0 {
+		return nil, errors.New("render: frameDelay must be at least 10ms")
+	}
+
+	g := &gif.GIF{LoopCount: 0}
+	for _, qr := range codes {
+		img, err := rasterize(qr, scale, border, color.White, color.Black)
+		if err != nil {
+			return nil, err
+		}
+		g.Image = append(g.Image, img)
+		g.Delay = append(g.Delay, delayHundredths)
+		g.Disposal = append(g.Disposal, gif.DisposalBackground)
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, g); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,177 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// HalftoneOptions configures ToHalftonePNG's blend of Background into the
+// symbol.
+type HalftoneOptions struct {
+	// Background is sampled (nearest neighbor) to fill the non-authoritative
+	// sub-pixels of every module. Required.
+	Background image.Image
+
+	// Scale is the side length, in pixels, of one module. Must be a
+	// positive multiple of SubPixels.
+	Scale int32
+
+	// Border is the number of light modules of quiet zone drawn around the
+	// symbol, rendered the same as any other light module (i.e. with the
+	// background showing through it too).
+	Border int32
+
+	// SubPixels is the number of sub-pixels per module edge that the
+	// module is divided into, e.g. 3 for a 3x3 sub-pixel grid. Must be a
+	// positive odd number, so that a single sub-pixel at the center is
+	// unambiguous; that center sub-pixel is always painted pure black or
+	// white to match the module's true color; the remaining sub-pixels
+	// show the background image, which is what gives the halftone look.
+	SubPixels int32
+}
+
+// ToHalftonePNG renders qr as a PNG that blends opts.Background into the
+// symbol, in the style of a "halftone QR code": each module is subdivided
+// into a SubPixels x SubPixels grid of sub-pixels, every one of which shows
+// the background image except the center sub-pixel, which is forced to the
+// module's true color so that a scanner reading just that single sample
+// point per module still recovers the original bit pattern.
+//
+// After rendering, ToHalftonePNG re-samples every module's center pixel and
+// verifies it decodes back to qr's own module grid, returning an error if
+// any position disagrees (which would indicate a bug in this function, not
+// a problem with qr or opts.Background).
+//
+// Returns an error if Scale <= 0, Border < 0, SubPixels <= 0, SubPixels
+// is even, Scale is not a multiple of SubPixels, the resulting image would
+// exceed maxRasterDimension pixels per side, or Background is nil.
+func ToHalftonePNG(qr *qrcodegen.QrCode, opts HalftoneOptions) ([]byte, error) {
+	if opts.Scale <= 0 {
+		return nil, fmt.Errorf("render: Scale must be positive, got %d", opts.Scale)
+	}
+	if opts.Border < 0 {
+		return nil, fmt.Errorf("render: Border must be non-negative, got %d", opts.Border)
+	}
+	if opts.SubPixels <= 0 || opts.SubPixels%2 == 0 {
+		return nil, fmt.Errorf("render: SubPixels must be a positive odd number, got %d", opts.SubPixels)
+	}
+	if opts.Scale%opts.SubPixels != 0 {
+		return nil, fmt.Errorf("render: Scale (%d) must be a multiple of SubPixels (%d)", opts.Scale, opts.SubPixels)
+	}
+	if opts.Background == nil {
+		return nil, errors.New("render: Background image is required")
+	}
+
+	subSize := opts.Scale / opts.SubPixels
+	center := opts.SubPixels / 2
+	dim, err := rasterDimension(qr.Size(), opts.Border, opts.Scale)
+	if err != nil {
+		return nil, err
+	}
+	bg := opts.Background.Bounds()
+
+	img := image.NewNRGBA(image.Rect(0, 0, dim, dim))
+	Drive(qr, opts.Border, &halftoneRenderer{
+		img:       img,
+		bg:        opts.Background,
+		bgBounds:  bg,
+		dim:       dim,
+		scale:     opts.Scale,
+		subSize:   subSize,
+		subPixels: opts.SubPixels,
+		center:    center,
+	})
+
+	if err := verifyHalftoneDecodable(img, qr, opts); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// halftoneRenderer is the ModuleRenderer that ToHalftonePNG drives to paint
+// one module's sub-pixel grid at a time.
+type halftoneRenderer struct {
+	img       *image.NRGBA
+	bg        image.Image
+	bgBounds  image.Rectangle
+	dim       int
+	scale     int32
+	subSize   int32
+	subPixels int32
+	center    int32
+}
+
+func (h *halftoneRenderer) Module(x, y int32, dark, isFunction bool) {
+	baseX, baseY := x*h.scale, y*h.scale
+	var moduleColor color.Color = color.White
+	if dark {
+		moduleColor = color.Black
+	}
+	for subRow := int32(0); subRow < h.subPixels; subRow++ {
+		for subCol := int32(0); subCol < h.subPixels; subCol++ {
+			var c color.Color
+			if subRow == h.center && subCol == h.center {
+				c = moduleColor
+			} else {
+				c = h.sampleBackground(baseX+subCol*h.subSize, baseY+subRow*h.subSize)
+			}
+			fillRect(h.img, int(baseX+subCol*h.subSize), int(baseY+subRow*h.subSize), int(h.subSize), int(h.subSize), c)
+		}
+	}
+}
+
+// sampleBackground nearest-neighbor samples bg at the position that
+// outX, outY (in output pixel coordinates, 0 to dim-1) maps to.
+func (h *halftoneRenderer) sampleBackground(outX, outY int32) color.Color {
+	bw, bh := h.bgBounds.Dx(), h.bgBounds.Dy()
+	bx := h.bgBounds.Min.X + int(outX)*bw/h.dim
+	by := h.bgBounds.Min.Y + int(outY)*bh/h.dim
+	return h.bg.At(bx, by)
+}
+
+func fillRect(img *image.NRGBA, x, y, w, h int, c color.Color) {
+	for dy := 0; dy < h; dy++ {
+		for dx := 0; dx < w; dx++ {
+			img.Set(x+dx, y+dy, c)
+		}
+	}
+}
+
+// verifyHalftoneDecodable re-samples the center pixel of every module in
+// img and confirms it matches qr's own module grid, i.e. that the halftone
+// treatment did not corrupt any bit a scanner would read.
+func verifyHalftoneDecodable(img *image.NRGBA, qr *qrcodegen.QrCode, opts HalftoneOptions) error {
+	half := opts.Scale / 2
+	size := qr.Size()
+	for y := int32(0); y < size; y++ {
+		for x := int32(0); x < size; x++ {
+			px := int((x+opts.Border)*opts.Scale + half)
+			py := int((y+opts.Border)*opts.Scale + half)
+			r, g, b, _ := img.At(px, py).RGBA()
+			sampledDark := r < 0x8000 && g < 0x8000 && b < 0x8000
+			if sampledDark != qr.GetModule(x, y) {
+				return errors.New("render: halftone rendering is not decodable at module " +
+					"(internal bug; this is not a Background or option problem)")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// ToPNGIconSet renders qr as a separate PNG at each pixel size in sizes
+// (e.g. 128, 256, 512, 1024), for an app-asset pipeline that needs the same
+// symbol at several fixed resolutions in one call.
+//
+// Each image uses the largest integer module scale that fits within its
+// target size with border modules of quiet zone, then is centered on a
+// canvas of exactly that target size, so every image in the set shares the
+// same border (in modules) and proportions, differing only by a few pixels
+// of centering slack from rounding.
+//
+// Returns a map from pixel size to PNG bytes, or an error if sizes is
+// empty, border is negative, opts requests interlacing (not supported by
+// the standard library encoder), or a size is not positive or too small to
+// fit even one pixel per module at the given border.
+func ToPNGIconSet(qr *qrcodegen.QrCode, sizes []int, border int32, opts PNGOptions) (map[int][]byte, error) {
+	if len(sizes) == 0 {
+		return nil, errors.New("render: no sizes given")
+	}
+	if border < 0 {
+		return nil, fmt.Errorf("render: border must be non-negative, got %d", border)
+	}
+	if opts.Interlace {
+		return nil, errors.New("render: interlaced PNG output is not supported by the standard library encoder")
+	}
+	dimModules := qr.Size() + 2*border
+
+	light := color.Color(color.White)
+	if opts.Transparent {
+		light = color.Transparent
+	}
+
+	result := make(map[int][]byte, len(sizes))
+	for _, size := range sizes {
+		if size <= 0 {
+			return nil, fmt.Errorf("render: size must be positive, got %d", size)
+		}
+		scale := int32(size) / dimModules
+		if scale < 1 {
+			return nil, fmt.Errorf("render: size %d is too small to fit %d modules (including border) at even 1 pixel each", size, dimModules)
+		}
+
+		symbol, err := rasterize(qr, scale, border, light, color.Black)
+		if err != nil {
+			return nil, err
+		}
+
+		canvas := image.NewRGBA(image.Rect(0, 0, size, size))
+		draw.Draw(canvas, canvas.Bounds(), image.NewUniform(light), image.Point{}, draw.Src)
+		offset := (size - symbol.Bounds().Dx()) / 2
+		draw.Draw(canvas, symbol.Bounds().Add(image.Pt(offset, offset)), symbol, image.Point{}, draw.Src)
+
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, canvas); err != nil {
+			return nil, err
+		}
+		result[size] = buf.Bytes()
+	}
+	return result, nil
+}
@@ -0,0 +1,277 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image/color"
+	"image/png"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ParsePNG decodes a PNG image rendered at scale pixels per module (such as ToPNG's output)
+// back into a module grid with the quiet zone stripped off, where
+// grid[y][x] is true for a dark module. scale and border must match the
+// values the image was rendered with; each module is sampled at its center
+// pixel, so this tolerates mild compression or color-profile noise but not
+// misaligned scale or border guesses.
+//
+// Returns an error if the image isn't square, its side length isn't a
+// multiple of scale, or border leaves too few modules.
+func ParsePNG(data []byte, scale, border int32) ([][]bool, error) {
+	if scale <= 0 {
+		return nil, fmt.Errorf("render: scale must be positive")
+	}
+	if border < 0 {
+		return nil, fmt.Errorf("render: border must be non-negative")
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("render: %w", err)
+	}
+	bounds := img.Bounds()
+	width, height := int32(bounds.Dx()), int32(bounds.Dy())
+	if width != height {
+		return nil, fmt.Errorf("render: image is %dx%d, not square", width, height)
+	}
+	if width%scale != 0 {
+		return nil, fmt.Errorf("render: image width %d is not a multiple of scale %d", width, scale)
+	}
+	return sampleGrid(width/scale, border, func(x, y int32) bool {
+		px := bounds.Min.X + int(x*scale+scale/2)
+		py := bounds.Min.Y + int(y*scale+scale/2)
+		return isDarkPixel(img.At(px, py))
+	})
+}
+
+// isDarkPixel reports whether c's luminance, a weighted sum of its RGB
+// components taken without gamma linearization, is below half of full scale.
+func isDarkPixel(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	lum := 0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)
+	return lum < 0.5*0xFFFF
+}
+
+// ParsePBM decodes a scale-pixels-per-module image in the NetPBM portable bitmap
+// format (ASCII "P1" or raw "P4") back into a module grid with the quiet
+// zone stripped off, the same way ParsePNG does. PBM's own convention of 1
+// meaning black and 0 meaning white maps directly to dark and light
+// modules.
+func ParsePBM(data []byte, scale, border int32) ([][]bool, error) {
+	if scale <= 0 {
+		return nil, fmt.Errorf("render: scale must be positive")
+	}
+	if border < 0 {
+		return nil, fmt.Errorf("render: border must be non-negative")
+	}
+	width, height, pixels, err := decodePBM(data)
+	if err != nil {
+		return nil, err
+	}
+	if width != height {
+		return nil, fmt.Errorf("render: image is %dx%d, not square", width, height)
+	}
+	if width%scale != 0 {
+		return nil, fmt.Errorf("render: image width %d is not a multiple of scale %d", width, scale)
+	}
+	return sampleGrid(width/scale, border, func(x, y int32) bool {
+		px := x*scale + scale/2
+		py := y*scale + scale/2
+		return pixels[py*width+px]
+	})
+}
+
+// decodePBM parses the NetPBM portable bitmap format into a flat row-major
+// slice of bools, true meaning black, per PBM's own convention.
+func decodePBM(data []byte) (width, height int32, pixels []bool, err error) {
+	r := bufio.NewReader(bytes.NewReader(data))
+	magic, err := readPBMToken(r)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("render: %w", err)
+	}
+	if magic != "P1" && magic != "P4" {
+		return 0, 0, nil, fmt.Errorf("render: not a PBM image (unrecognized magic number %q)", magic)
+	}
+	w, err := readPBMInt(r)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("render: %w", err)
+	}
+	h, err := readPBMInt(r)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("render: %w", err)
+	}
+
+	pixels = make([]bool, w*h)
+	if magic == "P1" {
+		for i := range pixels {
+			tok, err := readPBMToken(r)
+			if err != nil {
+				return 0, 0, nil, fmt.Errorf("render: %w", err)
+			}
+			pixels[i] = tok == "1"
+		}
+	} else {
+		// readPBMInt already consumed the single mandatory whitespace byte
+		// that terminates the header, via readPBMToken's token-boundary
+		// logic, so raw pixel data starts right here.
+		rowBytes := (w + 7) / 8
+		row := make([]byte, rowBytes)
+		for y := int32(0); y < h; y++ {
+			if _, err := readFull(r, row); err != nil {
+				return 0, 0, nil, fmt.Errorf("render: %w", err)
+			}
+			for x := int32(0); x < w; x++ {
+				bit := (row[x/8] >> uint(7-x%8)) & 1
+				pixels[y*w+x] = bit != 0
+			}
+		}
+	}
+	return w, h, pixels, nil
+}
+
+func readFull(r *bufio.Reader, buf []byte) (int, error) {
+	n := 0
+	for n < len(buf) {
+		m, err := r.Read(buf[n:])
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// readPBMToken reads one whitespace-delimited token, skipping "#" comments
+// that run to the end of their line, per the PBM header grammar.
+func readPBMToken(r *bufio.Reader) (string, error) {
+	var sb strings.Builder
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			if sb.Len() > 0 {
+				return sb.String(), nil
+			}
+			return "", err
+		}
+		switch {
+		case b == '#':
+			for {
+				b, err := r.ReadByte()
+				if err != nil || b == '\n' {
+					break
+				}
+			}
+		case b == ' ' || b == '\t' || b == '\n' || b == '\r':
+			if sb.Len() > 0 {
+				return sb.String(), nil
+			}
+		default:
+			sb.WriteByte(b)
+		}
+	}
+}
+
+func readPBMInt(r *bufio.Reader) (int32, error) {
+	tok, err := readPBMToken(r)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(tok)
+	if err != nil {
+		return 0, fmt.Errorf("invalid PBM header integer %q", tok)
+	}
+	return int32(n), nil
+}
+
+// sampleGrid builds a border-stripped module grid of the given total (with
+// border) width by calling dark once per module position, in the same
+// coordinate space as that total width.
+func sampleGrid(totalModules, border int32, dark func(x, y int32) bool) ([][]bool, error) {
+	if totalModules <= 2*border {
+		return nil, fmt.Errorf("render: %d modules wide, too few for a border of %d", totalModules, border)
+	}
+	size := totalModules - 2*border
+	grid := make([][]bool, size)
+	for y := int32(0); y < size; y++ {
+		row := make([]bool, size)
+		for x := int32(0); x < size; x++ {
+			row[x] = dark(x+border, y+border)
+		}
+		grid[y] = row
+	}
+	return grid, nil
+}
+
+var (
+	svgViewBoxRegexp = regexp.MustCompile(`viewBox="0 0 (\d+) (\d+)"`)
+	svgPathDRegexp   = regexp.MustCompile(`<path d="([^"]*)"`)
+	svgRunRegexp     = regexp.MustCompile(`^M(\d+),(\d+)h(\d+)v1h-\d+z$`)
+)
+
+// ParseSVG parses SVG markup in the format ToSvgString (or
+// ToSvgStringWithOptions with the default, empty FinderColor) produces,
+// back into a module grid with the quiet zone stripped off. border must
+// match the value the SVG was rendered with.
+//
+// Only the plain run-length-rectangle path format is understood; SVG
+// produced with a non-empty SvgOptions.FinderColor (which layers separate
+// finder-eye shapes on top) is not supported.
+func ParseSVG(svg string, border int32) ([][]bool, error) {
+	if border < 0 {
+		return nil, fmt.Errorf("render: border must be non-negative")
+	}
+	if strings.Count(svg, "<path") != 1 || strings.Contains(svg, "<circle") || strings.Count(svg, "<rect") != 1 {
+		return nil, fmt.Errorf("render: SVG with finder-eye styling is not supported by ParseSVG")
+	}
+
+	vb := svgViewBoxRegexp.FindStringSubmatch(svg)
+	if vb == nil {
+		return nil, fmt.Errorf("render: could not find a viewBox attribute")
+	}
+	width, _ := strconv.Atoi(vb[1])
+	height, _ := strconv.Atoi(vb[2])
+	if width != height {
+		return nil, fmt.Errorf("render: viewBox is %dx%d, not square", width, height)
+	}
+	dimension := int32(width)
+	if dimension <= 2*border {
+		return nil, fmt.Errorf("render: %d modules wide, too few for a border of %d", dimension, border)
+	}
+	size := dimension - 2*border
+
+	pathMatch := svgPathDRegexp.FindStringSubmatch(svg)
+	if pathMatch == nil {
+		return nil, fmt.Errorf("render: could not find a <path> element's d attribute")
+	}
+
+	grid := make([][]bool, size)
+	for i := range grid {
+		grid[i] = make([]bool, size)
+	}
+	for _, tok := range strings.Fields(pathMatch[1]) {
+		m := svgRunRegexp.FindStringSubmatch(tok)
+		if m == nil {
+			return nil, fmt.Errorf("render: unrecognized path command %q", tok)
+		}
+		x, _ := strconv.Atoi(m[1])
+		y, _ := strconv.Atoi(m[2])
+		runLen, _ := strconv.Atoi(m[3])
+		gx, gy := int32(x)-border, int32(y)-border
+		if gy < 0 || gy >= size || gx < 0 || gx+int32(runLen) > size {
+			return nil, fmt.Errorf("render: path command %q falls outside the declared border", tok)
+		}
+		for i := 0; i < runLen; i++ {
+			grid[gy][gx+int32(i)] = true
+		}
+	}
+	return grid, nil
+}
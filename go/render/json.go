@@ -0,0 +1,84 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// Rect is an axis-aligned, module-unit rectangle of dark pixels, for a
+// canvas-based frontend to fill directly without decoding an image.
+type Rect struct {
+	X int32 `json:"x"`
+	Y int32 `json:"y"`
+	W int32 `json:"w"`
+	H int32 `json:"h"`
+}
+
+// RectList is the JSON structure produced by ToJSON: the side length of the
+// square symbol (including its border) in module units, and the list of
+// dark rectangles that make it up.
+type RectList struct {
+	Size  int32  `json:"size"`
+	Rects []Rect `json:"rects"`
+}
+
+// ToRectList computes the dark-module rectangles of qr, offset by border,
+// without encoding them into a particular image format. Horizontally
+// adjacent dark modules in the same row are merged into a single rectangle.
+//
+// Panics if border is negative; use ToRectListChecked if border isn't
+// already known to be non-negative.
+func ToRectList(qr *qrcodegen.QrCode, border int32) RectList {
+	rl, err := ToRectListChecked(qr, border)
+	if err != nil {
+		panic(err)
+	}
+	return rl
+}
+
+// ToRectListChecked is like ToRectList, but returns an error instead of
+// panicking if border is negative.
+func ToRectListChecked(qr *qrcodegen.QrCode, border int32) (RectList, error) {
+	if border < 0 {
+		return RectList{}, fmt.Errorf("render: border must be non-negative, got %d", border)
+	}
+	size := qr.Size()
+	var rects []Rect
+	for y := int32(0); y < size; y++ {
+		for x := int32(0); x < size; {
+			if !qr.GetModule(x, y) {
+				x++
+				continue
+			}
+			runStart := x
+			for x < size && qr.GetModule(x, y) {
+				x++
+			}
+			rects = append(rects, Rect{X: runStart + border, Y: y + border, W: x - runStart, H: 1})
+		}
+	}
+	return RectList{Size: size + border*2, Rects: rects}, nil
+}
+
+// ToJSON renders qr as a compact JSON document holding the overall size and
+// the list of dark-module rectangles (see RectList), for shipping to a
+// JS canvas or WebGL frontend that draws the symbol itself instead of
+// fetching a whole image from the server.
+//
+// Returns an error if border is negative.
+func ToJSON(qr *qrcodegen.QrCode, border int32) ([]byte, error) {
+	rl, err := ToRectListChecked(qr, border)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(rl)
+}
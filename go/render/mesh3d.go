@@ -0,0 +1,193 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// Mesh3DOptions controls ToSTL and ToOBJ.
+type Mesh3DOptions struct {
+	// ModuleSizeMM is the side length of one module, in millimeters.
+	ModuleSizeMM float64
+
+	// BaseHeightMM is the thickness of the solid plate underneath the
+	// whole symbol.
+	BaseHeightMM float64
+
+	// ReliefHeightMM is the extra height extruded above the base plate
+	// under each dark module, for a tactile or 3D-printed relief code.
+	ReliefHeightMM float64
+
+	// Border is the number of light modules of quiet zone included in the
+	// base plate (the relief is never extruded over the border, since it
+	// is always light).
+	Border int32
+}
+
+type vec3 struct{ x, y, z float64 }
+
+func (a vec3) sub(b vec3) vec3 {
+	return vec3{a.x - b.x, a.y - b.y, a.z - b.z}
+}
+
+func (a vec3) cross(b vec3) vec3 {
+	return vec3{a.y*b.z - a.z*b.y, a.z*b.x - a.x*b.z, a.x*b.y - a.y*b.x}
+}
+
+func (a vec3) dot(b vec3) float64 {
+	return a.x*b.x + a.y*b.y + a.z*b.z
+}
+
+type triangle struct{ a, b, c vec3 }
+
+func (t triangle) normal() vec3 {
+	n := t.b.sub(t.a).cross(t.c.sub(t.a))
+	length := math.Sqrt(n.dot(n))
+	if length == 0 {
+		return vec3{}
+	}
+	return vec3{n.x / length, n.y / length, n.z / length}
+}
+
+// buildMesh returns the triangle mesh for qr under opts: a solid base
+// plate covering the whole bordered symbol, with a box extruded above it
+// for each horizontal run of dark modules (merged the same way the other
+// vector renderers merge runs).
+func buildMesh(qr *qrcodegen.QrCode, opts Mesh3DOptions) []triangle {
+	size := qr.Size()
+	dim := float64(size+2*opts.Border) * opts.ModuleSizeMM
+
+	var tris []triangle
+	tris = append(tris, boxTriangles(0, 0, 0, dim, dim, opts.BaseHeightMM)...)
+
+	for y := int32(0); y < size; y++ {
+		for x := int32(0); x < size; {
+			if !qr.GetModule(x, y) {
+				x++
+				continue
+			}
+			runStart := x
+			for x < size && qr.GetModule(x, y) {
+				x++
+			}
+			x0 := float64(runStart+opts.Border) * opts.ModuleSizeMM
+			y0 := float64(y+opts.Border) * opts.ModuleSizeMM
+			x1 := float64(x+opts.Border) * opts.ModuleSizeMM
+			y1 := y0 + opts.ModuleSizeMM
+			tris = append(tris, boxTriangles(x0, y0, opts.BaseHeightMM, x1, y1, opts.BaseHeightMM+opts.ReliefHeightMM)...)
+		}
+	}
+	return tris
+}
+
+// boxTriangles returns the 12 triangles (2 per face) of the axis-aligned
+// box from (x0, y0, z0) to (x1, y1, z1).
+func boxTriangles(x0, y0, z0, x1, y1, z1 float64) []triangle {
+	corners := [8]vec3{
+		{x0, y0, z0}, {x1, y0, z0}, {x1, y1, z0}, {x0, y1, z0},
+		{x0, y0, z1}, {x1, y0, z1}, {x1, y1, z1}, {x0, y1, z1},
+	}
+	center := vec3{(x0 + x1) / 2, (y0 + y1) / 2, (z0 + z1) / 2}
+	faces := [6][4]int{
+		{0, 1, 2, 3}, // bottom
+		{4, 5, 6, 7}, // top
+		{0, 1, 5, 4}, // front
+		{1, 2, 6, 5}, // right
+		{2, 3, 7, 6}, // back
+		{3, 0, 4, 7}, // left
+	}
+
+	tris := make([]triangle, 0, 12)
+	for _, f := range faces {
+		a, b, c, d := corners[f[0]], corners[f[1]], corners[f[2]], corners[f[3]]
+		tris = append(tris, orientOutward(a, b, c, center), orientOutward(a, c, d, center))
+	}
+	return tris
+}
+
+// orientOutward returns the triangle a, b, c, reversing its winding order
+// if needed so its normal points away from center. Since boxTriangles only
+// ever builds convex axis-aligned boxes, this is a cheap way to get every
+// face's outward orientation right without hand-deriving each face's
+// vertex order.
+func orientOutward(a, b, c, center vec3) triangle {
+	n := b.sub(a).cross(c.sub(a))
+	mid := vec3{(a.x + b.x + c.x) / 3, (a.y + b.y + c.y) / 3, (a.z + b.z + c.z) / 3}
+	if n.dot(mid.sub(center)) < 0 {
+		return triangle{a, c, b}
+	}
+	return triangle{a, b, c}
+}
+
+// ToSTL renders qr as an ASCII STL mesh: a solid base plate with dark
+// modules extruded above it in relief, for 3D printing signage or tactile
+// codes.
+//
+// Returns an error if opts.ModuleSizeMM or opts.BaseHeightMM is not
+// positive, or opts.ReliefHeightMM is negative.
+func ToSTL(qr *qrcodegen.QrCode, opts Mesh3DOptions) ([]byte, error) {
+	if err := validateMesh3DOptions(opts); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("solid qrcode\n")
+	for _, t := range buildMesh(qr, opts) {
+		n := t.normal()
+		fmt.Fprintf(&buf, "facet normal %s %s %s\n", fmtNum(n.x), fmtNum(n.y), fmtNum(n.z))
+		buf.WriteString("outer loop\n")
+		for _, v := range [3]vec3{t.a, t.b, t.c} {
+			fmt.Fprintf(&buf, "vertex %s %s %s\n", fmtNum(v.x), fmtNum(v.y), fmtNum(v.z))
+		}
+		buf.WriteString("endloop\nendfacet\n")
+	}
+	buf.WriteString("endsolid qrcode\n")
+	return buf.Bytes(), nil
+}
+
+// ToOBJ renders qr as a Wavefront OBJ mesh, built the same way as ToSTL.
+//
+// Returns an error if opts.ModuleSizeMM or opts.BaseHeightMM is not
+// positive, or opts.ReliefHeightMM is negative.
+func ToOBJ(qr *qrcodegen.QrCode, opts Mesh3DOptions) ([]byte, error) {
+	if err := validateMesh3DOptions(opts); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("o qrcode\n")
+	tris := buildMesh(qr, opts)
+	for _, t := range tris {
+		for _, v := range [3]vec3{t.a, t.b, t.c} {
+			fmt.Fprintf(&buf, "v %s %s %s\n", fmtNum(v.x), fmtNum(v.y), fmtNum(v.z))
+		}
+	}
+	for i := range tris {
+		base := i*3 + 1 // OBJ vertex indices are 1-based
+		fmt.Fprintf(&buf, "f %d %d %d\n", base, base+1, base+2)
+	}
+	return buf.Bytes(), nil
+}
+
+func validateMesh3DOptions(opts Mesh3DOptions) error {
+	if opts.ModuleSizeMM <= 0 {
+		return fmt.Errorf("render: ModuleSizeMM must be positive, got %g", opts.ModuleSizeMM)
+	}
+	if opts.BaseHeightMM <= 0 {
+		return fmt.Errorf("render: BaseHeightMM must be positive, got %g", opts.BaseHeightMM)
+	}
+	if opts.ReliefHeightMM < 0 {
+		return fmt.Errorf("render: ReliefHeightMM must be non-negative, got %g", opts.ReliefHeightMM)
+	}
+	return nil
+}
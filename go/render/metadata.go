@@ -0,0 +1,73 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// Metadata describes invisible provenance information that SVGWithMetadata
+// and PDFSheetOptions.Metadata can embed into a vector output, so an
+// asset-management system can recover which payload, and which version of
+// this library, produced a given file without re-scanning the symbol.
+//
+// Every field is optional; an empty Metadata embeds nothing.
+type Metadata struct {
+	// PayloadHash identifies the encoded payload, typically a
+	// hex-or-base64-encoded qrcodegen.QrCode.Fingerprint(); this package
+	// does not compute or interpret it.
+	PayloadHash string
+
+	// GeneratedAt is an RFC 3339 timestamp recording when the symbol was
+	// produced. This package has no wall-clock dependency of its own and
+	// does not set this automatically; the caller supplies it.
+	GeneratedAt string
+
+	// Generator names the library and version that produced the output,
+	// e.g. "github.com/nayuki/qrcodegen v1.2.3".
+	Generator string
+}
+
+// SVGWithMetadata behaves like qr.ToSvgStringWithOptionsChecked, but also
+// embeds meta as a Dublin Core record inside an RDF/XMP <metadata> element,
+// the standard SVG mechanism for attaching provenance that does not render
+// or affect scannability.
+//
+// If meta is the zero Metadata, this is equivalent to
+// qr.ToSvgStringWithOptionsChecked(border, svgOpts).
+func SVGWithMetadata(qr *qrcodegen.QrCode, border int32, svgOpts qrcodegen.SvgOptions, meta Metadata) (string, error) {
+	svg, err := qr.ToSvgStringWithOptionsChecked(border, svgOpts)
+	if err != nil {
+		return "", err
+	}
+	if meta == (Metadata{}) {
+		return svg, nil
+	}
+	return insertBeforeClosingSvgTag(svg, xmpMetadataFragment(meta)), nil
+}
+
+// xmpMetadataFragment renders meta as an RDF/XMP <metadata> element holding
+// a single Dublin Core rdf:Description, omitting any field left empty.
+func xmpMetadataFragment(meta Metadata) string {
+	var buf strings.Builder
+	buf.WriteString("\t<metadata>\n\t\t<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\" xmlns:dc=\"http://purl.org/dc/elements/1.1/\">\n\t\t\t<rdf:Description>\n")
+	if meta.PayloadHash != "" {
+		fmt.Fprintf(&buf, "\t\t\t\t<dc:identifier>%s</dc:identifier>\n", xmlEscapeText(meta.PayloadHash))
+	}
+	if meta.GeneratedAt != "" {
+		fmt.Fprintf(&buf, "\t\t\t\t<dc:date>%s</dc:date>\n", xmlEscapeText(meta.GeneratedAt))
+	}
+	if meta.Generator != "" {
+		fmt.Fprintf(&buf, "\t\t\t\t<dc:creator>%s</dc:creator>\n", xmlEscapeText(meta.Generator))
+	}
+	buf.WriteString("\t\t\t</rdf:Description>\n\t\t</rdf:RDF>\n\t</metadata>\n")
+	return buf.String()
+}
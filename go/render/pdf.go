@@ -0,0 +1,307 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// PDFSheetOptions configures the grid that ToPDFSheet lays QR Codes out in,
+// all measured in PDF points (1/72 inch), such as for printing onto
+// Avery-style adhesive label sheets.
+type PDFSheetOptions struct {
+	// PageWidth and PageHeight are the page size, e.g. 612x792 for US
+	// Letter.
+	PageWidth, PageHeight float64
+
+	// Rows and Cols are the number of label cells per page.
+	Rows, Cols int
+
+	// MarginX and MarginY are the blank space around the grid on each
+	// page.
+	MarginX, MarginY float64
+
+	// GapX and GapY are the blank space between adjacent cells.
+	GapX, GapY float64
+
+	// CaptionHeight reserves space at the bottom of each cell, below the
+	// symbol, for a caption. ToPDFSheet does not draw into this space;
+	// see ToPDFSheetWithCaptions for caption text.
+	CaptionHeight float64
+
+	// CaptionFontSize is the Helvetica point size used to draw captions in
+	// ToPDFSheetWithCaptions. If zero, it defaults to 0.6 * CaptionHeight.
+	CaptionFontSize float64
+
+	// Border is the number of light modules of quiet zone drawn around
+	// each symbol.
+	Border int32
+
+	// CMYKFill, if non-nil, fills the dark modules using the device CMYK
+	// color model instead of the default solid black, for artwork destined
+	// for an offset press rather than a desktop printer; a commercial print
+	// shop will generally reject RGB-only (or DeviceGray-only) assets.
+	CMYKFill *CMYK
+
+	// Bleed extends the page's MediaBox by this many points beyond each
+	// edge of PageWidth x PageHeight, for artwork a print shop will trim
+	// down to the nominal page size after printing. The nominal size is
+	// still recorded as the page's TrimBox. If zero, no bleed is added and
+	// no TrimBox is written.
+	Bleed float64
+
+	// CropMarks, if true, draws registration crop marks just outside each
+	// corner of the trim box, in the bleed area, for the print shop's
+	// cutter to align against. Ignored if Bleed is zero, since there is no
+	// bleed area to draw them in.
+	CropMarks bool
+
+	// Metadata, if not the zero value, is written into the PDF's Document
+	// Information dictionary, for asset-management systems that want to
+	// track provenance. See Metadata's doc comment for field meanings.
+	Metadata Metadata
+}
+
+// CMYK is a color expressed in the CMYK color model used by offset
+// printing presses, with each component in [0, 1], for
+// PDFSheetOptions.CMYKFill.
+type CMYK struct {
+	C, M, Y, K float64
+}
+
+// ToPDFSheet lays codes out across one or more pages of a PDF document in
+// the grid described by opts, filling pages left to right, top to bottom,
+// before advancing to the next page. Each symbol is scaled uniformly (and
+// centered horizontally) to fit its cell above the reserved caption space.
+//
+// Returns an error if codes is empty, or if opts describes a grid with no
+// room for a symbol (Rows, Cols <= 0, or a cell that is not positive-sized
+// after subtracting CaptionHeight).
+func ToPDFSheet(codes []*qrcodegen.QrCode, opts PDFSheetOptions) ([]byte, error) {
+	return toPDFSheet(codes, nil, opts)
+}
+
+// ToPDFSheetWithCaptions behaves like ToPDFSheet, but also draws a caption
+// string (e.g. a serial number or name) centered beneath each symbol, in
+// the standard Helvetica font, within the space reserved by
+// opts.CaptionHeight.
+//
+// Standard PDF fonts such as Helvetica are guaranteed to be available in
+// every conforming reader, so (unlike a custom font) this does not require
+// embedding any font program in the output file.
+//
+// captions may be shorter than codes; missing or empty entries draw no
+// caption for that symbol. Returns an error if len(captions) > len(codes),
+// or for the same reasons as ToPDFSheet.
+func ToPDFSheetWithCaptions(codes []*qrcodegen.QrCode, captions []string, opts PDFSheetOptions) ([]byte, error) {
+	if len(captions) > len(codes) {
+		return nil, errors.New("render: more captions than QR Codes")
+	}
+	return toPDFSheet(codes, captions, opts)
+}
+
+func toPDFSheet(codes []*qrcodegen.QrCode, captions []string, opts PDFSheetOptions) ([]byte, error) {
+	if len(codes) == 0 {
+		return nil, errors.New("render: no QR Codes given")
+	}
+	if opts.Rows <= 0 || opts.Cols <= 0 {
+		return nil, errors.New("render: Rows and Cols must be positive")
+	}
+	cellW := (opts.PageWidth - 2*opts.MarginX - float64(opts.Cols-1)*opts.GapX) / float64(opts.Cols)
+	cellH := (opts.PageHeight - 2*opts.MarginY - float64(opts.Rows-1)*opts.GapY) / float64(opts.Rows)
+	qrAreaH := cellH - opts.CaptionHeight
+	if cellW <= 0 || qrAreaH <= 0 {
+		return nil, errors.New("render: cell size must be positive after margins, gaps, and caption height")
+	}
+
+	perPage := opts.Rows * opts.Cols
+	w := newPdfWriter()
+	pagesObj := w.reserve()
+	var fontObj int
+	resources := "<< >>"
+	if captions != nil {
+		fontObj = w.writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>")
+		resources = fmt.Sprintf("<< /Font << /F1 %d 0 R >> >>", fontObj)
+	}
+	var pageObjs []int
+
+	for start := 0; start < len(codes); start += perPage {
+		end := start + perPage
+		if end > len(codes) {
+			end = len(codes)
+		}
+		var pageCaptions []string
+		if captions != nil {
+			capEnd := end
+			if capEnd > len(captions) {
+				capEnd = len(captions)
+			}
+			if start < capEnd {
+				pageCaptions = captions[start:capEnd]
+			}
+		}
+		content := buildSheetPageContent(codes[start:end], pageCaptions, opts, cellW, cellH, qrAreaH)
+		contentObj := w.writeStream(content)
+		mediaBox := fmt.Sprintf("[0 0 %s %s]", fmtNum(opts.PageWidth), fmtNum(opts.PageHeight))
+		trimBox := ""
+		if opts.Bleed > 0 {
+			mediaBox = fmt.Sprintf("[%s %s %s %s]",
+				fmtNum(-opts.Bleed), fmtNum(-opts.Bleed),
+				fmtNum(opts.PageWidth+opts.Bleed), fmtNum(opts.PageHeight+opts.Bleed))
+			trimBox = fmt.Sprintf(" /TrimBox [0 0 %s %s]", fmtNum(opts.PageWidth), fmtNum(opts.PageHeight))
+		}
+		pageObj := w.writeObject(fmt.Sprintf(
+			"<< /Type /Page /Parent %d 0 R /MediaBox %s%s /Contents %d 0 R /Resources %s >>",
+			pagesObj, mediaBox, trimBox, contentObj, resources))
+		pageObjs = append(pageObjs, pageObj)
+	}
+
+	var kids bytes.Buffer
+	for _, p := range pageObjs {
+		fmt.Fprintf(&kids, "%d 0 R ", p)
+	}
+	w.writeObjectAt(pagesObj, fmt.Sprintf("<< /Type /Pages /Kids [ %s] /Count %d >>", kids.String(), len(pageObjs)))
+
+	catalogObj := w.writeObject(fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj))
+
+	var infoObj int
+	if opts.Metadata != (Metadata{}) {
+		infoObj = w.writeInfoDict(opts.Metadata)
+	}
+
+	return w.finish(catalogObj, infoObj), nil
+}
+
+// buildSheetPageContent returns the PDF content stream that draws one page
+// of a label sheet: up to Rows*Cols symbols from codes, placed left to
+// right, top to bottom, each with its corresponding caption from captions
+// (if any) centered beneath it.
+func buildSheetPageContent(codes []*qrcodegen.QrCode, captions []string, opts PDFSheetOptions, cellW, cellH, qrAreaH float64) []byte {
+	var buf bytes.Buffer
+	if opts.Bleed > 0 && opts.CropMarks {
+		writeCropMarkOps(&buf, opts.PageWidth, opts.PageHeight)
+	}
+	if opts.CMYKFill != nil {
+		c := opts.CMYKFill
+		fmt.Fprintf(&buf, "%s %s %s %s k\n", fmtNum(c.C), fmtNum(c.M), fmtNum(c.Y), fmtNum(c.K))
+	} else {
+		buf.WriteString("0 g\n")
+	}
+	for i, qr := range codes {
+		row := i / opts.Cols
+		col := i % opts.Cols
+		cellX := opts.MarginX + float64(col)*(cellW+opts.GapX)
+		cellTopY := opts.PageHeight - opts.MarginY - float64(row)*(cellH+opts.GapY)
+
+		dim := float64(qr.Size() + opts.Border*2)
+		scale := cellW / dim
+		if s := qrAreaH / dim; s < scale {
+			scale = s
+		}
+		tx := cellX + (cellW-dim*scale)/2
+		ty := cellTopY
+
+		fmt.Fprintf(&buf, "q\n%s 0 0 %s %s %s cm\n", fmtNum(scale), fmtNum(-scale), fmtNum(tx), fmtNum(ty))
+		writeModulePathOps(&buf, qr, opts.Border)
+		buf.WriteString("f\nQ\n")
+
+		if i < len(captions) && captions[i] != "" {
+			writeCaptionOps(&buf, captions[i], opts, cellX, cellW, cellTopY-qrAreaH)
+		}
+	}
+	return buf.Bytes()
+}
+
+// cropMarkGapPt and cropMarkLengthPt size the registration marks drawn by
+// writeCropMarkOps: each mark is a short line starting cropMarkGapPt
+// outside the trim edge and running a further cropMarkLengthPt into the
+// bleed area, following common prepress convention.
+const (
+	cropMarkGapPt    = 6.0
+	cropMarkLengthPt = 18.0
+)
+
+// writeCropMarkOps appends stroke operators drawing an L-shaped
+// registration mark just outside each corner of the pageWidth x
+// pageHeight trim box, for a print shop's cutter to align against.
+func writeCropMarkOps(buf *bytes.Buffer, pageWidth, pageHeight float64) {
+	buf.WriteString("0 G\n0.5 w\n")
+	corners := []struct{ x, y, dx, dy float64 }{
+		{0, 0, -1, -1},
+		{pageWidth, 0, 1, -1},
+		{0, pageHeight, -1, 1},
+		{pageWidth, pageHeight, 1, 1},
+	}
+	for _, c := range corners {
+		fmt.Fprintf(buf, "%s %s m\n%s %s l\nS\n",
+			fmtNum(c.x+c.dx*cropMarkGapPt), fmtNum(c.y),
+			fmtNum(c.x+c.dx*(cropMarkGapPt+cropMarkLengthPt)), fmtNum(c.y))
+		fmt.Fprintf(buf, "%s %s m\n%s %s l\nS\n",
+			fmtNum(c.x), fmtNum(c.y+c.dy*cropMarkGapPt),
+			fmtNum(c.x), fmtNum(c.y+c.dy*(cropMarkGapPt+cropMarkLengthPt)))
+	}
+}
+
+// writeCaptionOps appends text-showing operators that center caption
+// horizontally within a cell of width cellW starting at cellX, with its
+// baseline placed a small gap below baselineTop (the bottom of the QR
+// area).
+func writeCaptionOps(buf *bytes.Buffer, caption string, opts PDFSheetOptions, cellX, cellW, baselineTop float64) {
+	fontSize := opts.CaptionFontSize
+	if fontSize <= 0 {
+		fontSize = 0.6 * opts.CaptionHeight
+	}
+	// Helvetica has no fixed width; this approximates its average advance
+	// width well enough to roughly center short captions.
+	approxWidth := float64(len([]rune(caption))) * 0.5 * fontSize
+	x := cellX + (cellW-approxWidth)/2
+	y := baselineTop - fontSize
+	fmt.Fprintf(buf, "BT\n/F1 %s Tf\n%s %s Td\n(%s) Tj\nET\n", fmtNum(fontSize), fmtNum(x), fmtNum(y), pdfEscapeString(caption))
+}
+
+// pdfEscapeString escapes the characters that are special inside a PDF
+// literal string, i.e. "(", ")", and "\".
+func pdfEscapeString(s string) string {
+	var buf bytes.Buffer
+	for _, r := range s {
+		switch r {
+		case '(', ')', '\\':
+			buf.WriteByte('\\')
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
+
+// writeModulePathOps appends "x y w h re" rectangle operators covering
+// every dark module of qr, offset by border, merging horizontally adjacent
+// dark modules in the same row into one rectangle.
+func writeModulePathOps(buf *bytes.Buffer, qr *qrcodegen.QrCode, border int32) {
+	size := qr.Size()
+	for y := int32(0); y < size; y++ {
+		for x := int32(0); x < size; {
+			if !qr.GetModule(x, y) {
+				x++
+				continue
+			}
+			runStart := x
+			for x < size && qr.GetModule(x, y) {
+				x++
+			}
+			fmt.Fprintf(buf, "%d %d %d 1 re\n", runStart+border, y+border, x-runStart)
+		}
+	}
+}
+
+func fmtNum(v float64) string {
+	return fmt.Sprintf("%g", v)
+}
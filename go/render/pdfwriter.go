@@ -0,0 +1,102 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// pdfWriter incrementally assembles a minimal, uncompressed PDF file: a
+// flat sequence of indirect objects followed by a cross-reference table and
+// trailer, which is all that the grid and label layouts in this package
+// need. It does not support PDF features (fonts, images, compression)
+// beyond what those layouts use directly.
+type pdfWriter struct {
+	buf     bytes.Buffer
+	offsets []int64 // offsets[i] is the byte offset of object number i+1; 0 until known
+}
+
+func newPdfWriter() *pdfWriter {
+	w := &pdfWriter{}
+	w.buf.WriteString("%PDF-1.4\n")
+	return w
+}
+
+// reserve allocates an object number whose content will be supplied later
+// via writeObjectAt, for forward references such as a Pages object that
+// must name its Kids before they are known.
+func (w *pdfWriter) reserve() int {
+	w.offsets = append(w.offsets, 0)
+	return len(w.offsets)
+}
+
+// writeObject appends a new indirect object with the given body (the part
+// between "obj" and "endobj") and returns its object number.
+func (w *pdfWriter) writeObject(body string) int {
+	num := w.reserve()
+	w.writeObjectAt(num, body)
+	return num
+}
+
+// writeObjectAt writes the body of a previously reserved object number at
+// the writer's current position.
+func (w *pdfWriter) writeObjectAt(num int, body string) {
+	w.offsets[num-1] = int64(w.buf.Len())
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", num, body)
+}
+
+// writeStream writes a new indirect stream object holding data verbatim
+// (no filters) and returns its object number.
+func (w *pdfWriter) writeStream(data []byte) int {
+	num := w.reserve()
+	w.offsets[num-1] = int64(w.buf.Len())
+	fmt.Fprintf(&w.buf, "%d 0 obj\n<< /Length %d >>\nstream\n", num, len(data))
+	w.buf.Write(data)
+	w.buf.WriteString("\nendstream\nendobj\n")
+	return num
+}
+
+// writeInfoDict writes a Document Information dictionary (PDF 1.4 section
+// 9.2.1) recording meta's fields under /Producer and /CreationDate, plus a
+// custom /PayloadHash key for meta.PayloadHash, and returns its object
+// number, for finish's infoObj parameter. Readers that don't recognize
+// /PayloadHash simply ignore it, per the PDF spec's rule for unrecognized
+// Info dictionary keys.
+func (w *pdfWriter) writeInfoDict(meta Metadata) int {
+	var entries []string
+	if meta.Generator != "" {
+		entries = append(entries, fmt.Sprintf("/Producer (%s)", pdfEscapeString(meta.Generator)))
+	}
+	if meta.GeneratedAt != "" {
+		entries = append(entries, fmt.Sprintf("/CreationDate (%s)", pdfEscapeString(meta.GeneratedAt)))
+	}
+	if meta.PayloadHash != "" {
+		entries = append(entries, fmt.Sprintf("/PayloadHash (%s)", pdfEscapeString(meta.PayloadHash)))
+	}
+	return w.writeObject("<< " + strings.Join(entries, " ") + " >>")
+}
+
+// finish writes the cross-reference table and trailer (naming rootObj as
+// the document Catalog, and infoObj as the Document Information
+// dictionary if non-zero) and returns the complete PDF file.
+func (w *pdfWriter) finish(rootObj, infoObj int) []byte {
+	xrefOffset := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n", len(w.offsets)+1)
+	w.buf.WriteString("0000000000 65535 f \n")
+	for _, off := range w.offsets {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root %d 0 R", len(w.offsets)+1, rootObj)
+	if infoObj != 0 {
+		fmt.Fprintf(&w.buf, " /Info %d 0 R", infoObj)
+	}
+	fmt.Fprintf(&w.buf, " >>\nstartxref\n%d\n%%%%EOF\n", xrefOffset)
+	return w.buf.Bytes()
+}
@@ -0,0 +1,142 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// PipelineOptions configures CSVToPDF and CSVToZIP: how CSV columns map to
+// a payload and an optional caption, and how the resulting symbols are laid
+// out.
+type PipelineOptions struct {
+	// PayloadTemplate and CaptionTemplate are strings containing
+	// "{colname}" placeholders, substituted per row from the CSV header.
+	// CaptionTemplate may be empty, in which case no caption is drawn.
+	PayloadTemplate, CaptionTemplate string
+
+	// ErrorCorrectionLevel is passed to EncodeText for every row.
+	ErrorCorrectionLevel qrcodegen.QrCodeEcc
+
+	// Sheet lays symbols out into a PDF document; see
+	// ToPDFSheetWithCaptions. Its Border is also used as the quiet zone for
+	// each SVG file CSVToZIP writes.
+	Sheet PDFSheetOptions
+
+	// OnProgress, if non-nil, is called after each row is encoded, with the
+	// number of rows completed so far and the total row count.
+	OnProgress func(done, total int)
+}
+
+// CSVToPDF reads a header row and data rows from r, substitutes each row's
+// columns into opts.PayloadTemplate and opts.CaptionTemplate, encodes one QR
+// Code per row, and lays them out into a PDF document via
+// ToPDFSheetWithCaptions, for a ticketing or asset-tagging system to drive
+// directly from its own row data instead of shelling out to a CLI.
+//
+// Returns an error if r is not valid CSV, if a row fails to encode, or for
+// the reasons ToPDFSheetWithCaptions does.
+func CSVToPDF(r io.Reader, opts PipelineOptions) ([]byte, error) {
+	codes, captions, err := encodeCSVRows(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	return ToPDFSheetWithCaptions(codes, captions, opts.Sheet)
+}
+
+// CSVToZIP behaves like CSVToPDF, but returns a ZIP archive containing one
+// SVG file per row (named by its 1-based row number) instead of laying the
+// symbols out into a PDF sheet.
+func CSVToZIP(r io.Reader, opts PipelineOptions) ([]byte, error) {
+	codes, _, err := encodeCSVRows(r, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for i, qr := range codes {
+		f, err := zw.Create(fmt.Sprintf("%04d.svg", i+1))
+		if err != nil {
+			return nil, fmt.Errorf("render: writing %04d.svg: %w", i+1, err)
+		}
+		if _, err := f.Write([]byte(qr.ToSvgString(opts.Sheet.Border))); err != nil {
+			return nil, fmt.Errorf("render: writing %04d.svg: %w", i+1, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("render: closing ZIP: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeCSVRows reads CSV from r and encodes one QR Code per data row,
+// substituting opts.PayloadTemplate and opts.CaptionTemplate from the
+// header row's column names, reporting progress via opts.OnProgress.
+func encodeCSVRows(r io.Reader, opts PipelineOptions) ([]*qrcodegen.QrCode, []string, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("render: reading CSV header: %w", err)
+	}
+
+	var rows [][]string
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("render: reading CSV row %d: %w", len(rows)+2, err)
+		}
+		rows = append(rows, row)
+	}
+
+	codes := make([]*qrcodegen.QrCode, len(rows))
+	var captions []string
+	if opts.CaptionTemplate != "" {
+		captions = make([]string, len(rows))
+	}
+	for i, row := range rows {
+		payload := substituteColumns(opts.PayloadTemplate, header, row)
+		qr, err := qrcodegen.EncodeText(payload, opts.ErrorCorrectionLevel)
+		if err != nil {
+			return nil, nil, fmt.Errorf("render: row %d: %w", i+2, err)
+		}
+		codes[i] = qr
+		if captions != nil {
+			captions[i] = substituteColumns(opts.CaptionTemplate, header, row)
+		}
+		if opts.OnProgress != nil {
+			opts.OnProgress(i+1, len(rows))
+		}
+	}
+	return codes, captions, nil
+}
+
+// substituteColumns replaces each "{colname}" placeholder in tmpl with the
+// value of the matching column in row, per header's column names. A
+// placeholder naming a column beyond the end of a short row, or a column
+// name not present in header, is left unsubstituted.
+func substituteColumns(tmpl string, header, row []string) string {
+	result := tmpl
+	for i, name := range header {
+		if i >= len(row) {
+			break
+		}
+		result = strings.ReplaceAll(result, "{"+name+"}", row[i])
+	}
+	return result
+}
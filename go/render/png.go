@@ -0,0 +1,78 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Package render converts a *qrcodegen.QrCode into pixel- and
+// document-based output formats (PNG, and more to come), as an alternative
+// to the vector SVG output built into the core package.
+package render
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"image/png"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// PNGOptions controls pixel-format details of ToPNG, beyond the symbol's
+// own size and border.
+type PNGOptions struct {
+	// Transparent, if true, makes light modules and the border transparent
+	// instead of white, so the symbol can be overlaid on colored
+	// backgrounds.
+	Transparent bool
+
+	// Interlace requests Adam7 interlacing. Go's standard library PNG
+	// encoder does not support writing interlaced images, so ToPNG returns
+	// an error if this is set rather than silently ignoring it.
+	Interlace bool
+
+	// Inverted, if true, swaps the light and dark colors, producing a
+	// light-on-dark image for dark-themed UIs. Many scanners are tuned for
+	// the conventional dark-on-light orientation and may refuse to read an
+	// inverted symbol; callers setting this should pair it with a
+	// CheckScannability call using ScannabilityOptions.Inverted to surface
+	// that risk to whoever is producing the symbol.
+	Inverted bool
+}
+
+// ToPNG renders qr as a 2-color indexed (palette) PNG image, which the
+// encoder stores at a low bit depth for a small file size. Each module
+// occupies a scale-by-scale block of pixels, surrounded by a border of
+// light modules border modules wide.
+//
+// Returns an error if scale <= 0, border < 0, the resulting image would
+// exceed maxRasterDimension pixels per side, or opts requests interlacing,
+// which is not supported.
+func ToPNG(qr *qrcodegen.QrCode, scale, border int32, opts PNGOptions) ([]byte, error) {
+	if err := validateScaleBorder(scale, border); err != nil {
+		return nil, err
+	}
+	if opts.Interlace {
+		return nil, errors.New("render: interlaced PNG output is not supported by the standard library encoder")
+	}
+
+	light := color.Color(color.White)
+	if opts.Transparent {
+		light = color.Transparent
+	}
+	dark := color.Color(color.Black)
+	if opts.Inverted {
+		light, dark = dark, light
+	}
+	img, err := rasterize(qr, scale, border, light, dark)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
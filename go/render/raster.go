@@ -0,0 +1,94 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// palettedRenderer is a ModuleRenderer that paints each dark module as a
+// scale-by-scale block of pixel index 1 into img, leaving light modules at
+// the background index 0. It is the ModuleRenderer that ToPNG and
+// ToAnimatedGIF drive via rasterize.
+type palettedRenderer struct {
+	img   *image.Paletted
+	scale int32
+}
+
+func (p *palettedRenderer) Module(x, y int32, dark, isFunction bool) {
+	if !dark {
+		return
+	}
+	baseX := int(x * p.scale)
+	baseY := int(y * p.scale)
+	for dy := 0; dy < int(p.scale); dy++ {
+		for dx := 0; dx < int(p.scale); dx++ {
+			p.img.SetColorIndex(baseX+dx, baseY+dy, 1)
+		}
+	}
+}
+
+// validateScaleBorder returns an error if scale <= 0 or border < 0,
+// for the exported entry points (ToPNG, ToAnimatedGIF, ...) to check
+// before calling rasterize, so a bad scale or border caller-supplied
+// value (e.g. from a web request) is reported instead of crashing the
+// process.
+func validateScaleBorder(scale, border int32) error {
+	if scale <= 0 {
+		return fmt.Errorf("render: scale must be positive, got %d", scale)
+	}
+	if border < 0 {
+		return fmt.Errorf("render: border must be non-negative, got %d", border)
+	}
+	return nil
+}
+
+// rasterize draws qr into a 2-color indexed image, with each module
+// occupying a scale-by-scale block of pixels and a border modules wide on
+// every side. Index 0 is light, index 1 is dark.
+//
+// The caller must have already validated scale and border via
+// validateScaleBorder; this is an internal invariant, not re-checked here.
+func rasterize(qr *qrcodegen.QrCode, scale, border int32, light, dark color.Color) (*image.Paletted, error) {
+	dim, err := rasterDimension(qr.Size(), border, scale)
+	if err != nil {
+		return nil, err
+	}
+	palette := color.Palette{light, dark}
+	img := image.NewPaletted(image.Rect(0, 0, dim, dim), palette)
+
+	Drive(qr, border, &palettedRenderer{img: img, scale: scale})
+	return img, nil
+}
+
+// maxRasterDimension caps the pixel width/height this package's renderers
+// will attempt to allocate. scale and border often come directly from an
+// untrusted caller (e.g. a web request; see ToPNG's doc comment), so
+// without this cap a large-enough pair could overflow the int32 arithmetic
+// below, or make the process attempt to allocate an unreasonably large
+// image.
+const maxRasterDimension = 1 << 16
+
+// rasterDimension returns the pixel width/height of a size-by-size QR Code
+// rendered at scale with border modules of quiet zone on every side,
+// computed in 64-bit arithmetic so an overflow of the int32 inputs is
+// detected rather than silently wrapping, and rejected if the result
+// exceeds maxRasterDimension. The caller must have already validated that
+// scale > 0 and border >= 0, e.g. via validateScaleBorder; this is an
+// internal invariant, not re-checked here.
+func rasterDimension(size, border, scale int32) (int, error) {
+	dim := (int64(size) + int64(border)*2) * int64(scale)
+	if dim > maxRasterDimension {
+		return 0, fmt.Errorf("render: resulting image dimension %d exceeds the %d-pixel limit", dim, maxRasterDimension)
+	}
+	return int(dim), nil
+}
@@ -0,0 +1,81 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// TestRasterDimensionRejectsOverflow checks that rasterDimension reports an
+// error, rather than returning a wrapped or truncated result, for inputs
+// whose product would overflow int32 arithmetic. rasterDimension computes
+// in int64 specifically so this holds on 32-bit platforms (GOARCH=386,
+// arm) where int is only 32 bits, not just on 64-bit ones; these cases are
+// chosen to overflow int32 while still fitting comfortably in int64, so
+// the test catches a regression to int32 arithmetic regardless of the
+// platform it's built for.
+func TestRasterDimensionRejectsOverflow(t *testing.T) {
+	tests := []struct {
+		name                string
+		size, border, scale int32
+	}{
+		{"scale alone overflows int32", 177, 0, math.MaxInt32},
+		{"border alone overflows int32", 177, math.MaxInt32, 1},
+		{"product of in-range values overflows int32", 1 << 16, 0, 1 << 16},
+		{"size plus border overflows int32", math.MaxInt32 - 1, math.MaxInt32 - 1, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dim, err := rasterDimension(test.size, test.border, test.scale)
+			if err == nil {
+				t.Errorf("rasterDimension(%d, %d, %d) = %d, nil; want an error", test.size, test.border, test.scale, dim)
+			}
+		})
+	}
+}
+
+// TestRasterDimensionAtLimit checks that rasterDimension accepts the
+// largest dimension maxRasterDimension allows and rejects one pixel more,
+// the boundary the overflow guard is built around.
+func TestRasterDimensionAtLimit(t *testing.T) {
+	if dim, err := rasterDimension(maxRasterDimension, 0, 1); err != nil || dim != maxRasterDimension {
+		t.Errorf("rasterDimension(maxRasterDimension, 0, 1) = %d, %v; want %d, nil", dim, err, maxRasterDimension)
+	}
+	if _, err := rasterDimension(maxRasterDimension+1, 0, 1); err == nil {
+		t.Errorf("rasterDimension(maxRasterDimension+1, 0, 1) succeeded; want an error")
+	}
+}
+
+// TestToPNGRejectsOversizedScale checks that the same overflow guard is
+// actually wired up through ToPNG's public entry point, not just present
+// in the unexported helper.
+func TestToPNGRejectsOversizedScale(t *testing.T) {
+	qr, err := qrcodegen.EncodeText("overflow guard test", qrcodegen.Low)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ToPNG(qr, math.MaxInt32, 0, PNGOptions{}); err == nil {
+		t.Error("ToPNG with scale = math.MaxInt32 succeeded; want an error")
+	}
+}
+
+// TestToTIFFRejectsOversizedScale is TestToPNGRejectsOversizedScale's
+// counterpart for ToTIFF, which rasterizes independently of ToPNG but
+// shares the same validateScaleBorder and rasterDimension guards.
+func TestToTIFFRejectsOversizedScale(t *testing.T) {
+	qr, err := qrcodegen.EncodeText("overflow guard test", qrcodegen.Low)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ToTIFF(qr, math.MaxInt32, 0, TIFFUncompressed); err == nil {
+		t.Error("ToTIFF with scale = math.MaxInt32 succeeded; want an error")
+	}
+}
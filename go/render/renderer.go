@@ -0,0 +1,61 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import "github.com/nayuki/qrcodegen"
+
+// ModuleRenderer receives one callback per position of a symbol's module
+// grid, including its border (quiet zone), so that third parties can
+// implement exotic visual styles (rounded dots, logos over function
+// modules, etc.) without needing access to this package's PNG/GIF-specific
+// code. Drive(sym, border, r) calls Module once per position in row-major
+// order.
+type ModuleRenderer interface {
+	// Module is called for the position at output coordinates (x, y),
+	// which run from 0 to sym.Size()+2*border-1 inclusive; positions
+	// outside the symbol proper (within the border) have dark and
+	// isFunction both false. dark reports the module's color, and
+	// isFunction reports whether it belongs to a function pattern (finder,
+	// timing, alignment, format, or version) rather than to encoded data,
+	// which a style might want to render differently (e.g. leaving finder
+	// patterns square while rounding data module dots).
+	Module(x, y int32, dark, isFunction bool)
+}
+
+// Drive calls r.Module once for every position of sym's module grid,
+// including a border modules wide of light, non-function positions on
+// every side, in row-major order (all of row 0 left to right, then row 1,
+// and so on).
+//
+// sym only needs to implement qrcodegen.Symbol; isFunction is always false
+// unless sym also implements qrcodegen.FunctionModuleSymbol, which QrCode
+// does. In this package, ToPNG, ToAnimatedGIF, ToPNGIconSet, Draw, and
+// ToHalftonePNG go through Drive; those functions take a *qrcodegen.QrCode,
+// and the other renderers read the QrCode's modules directly.
+//
+// Panics if border is negative.
+func Drive(sym qrcodegen.Symbol, border int32, r ModuleRenderer) {
+	if border < 0 {
+		panic("border must be non-negative")
+	}
+	fsym, _ := sym.(qrcodegen.FunctionModuleSymbol)
+	size := sym.Size()
+	for y := -border; y < size+border; y++ {
+		for x := -border; x < size+border; x++ {
+			inBounds := x >= 0 && x < size && y >= 0 && y < size
+			var dark, isFunction bool
+			if inBounds {
+				dark = sym.ModuleAt(x, y)
+				if fsym != nil {
+					isFunction = fsym.IsFunctionModuleAt(x, y)
+				}
+			}
+			r.Module(x+border, y+border, dark, isFunction)
+		}
+	}
+}
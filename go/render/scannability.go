@@ -0,0 +1,156 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// Warning is one scannability problem found by CheckScannability. Code is a
+// short machine-readable tag (e.g. "quiet_zone_too_small") suitable for
+// filtering or counting; Message is a human-readable explanation suitable
+// for a print-run report.
+type Warning struct {
+	Code    string
+	Message string
+}
+
+// ScannabilityOptions describes how a symbol is planned to be produced and
+// viewed, for CheckScannability to evaluate. A zero value in any numeric
+// field means "not specified", and CheckScannability skips the checks that
+// field would otherwise drive.
+type ScannabilityOptions struct {
+	// Border is the number of light quiet-zone modules planned around the
+	// symbol.
+	Border int32
+
+	// DarkColor and LightColor are the colors the symbol will be printed
+	// or displayed in. If either is nil, contrast and color-inversion
+	// checks are skipped.
+	DarkColor, LightColor color.Color
+
+	// ModuleSizeMM and ScanDistanceMM are the physical size of one module
+	// and the intended scanning distance, both in millimeters. If either
+	// is zero, the module-size check is skipped.
+	ModuleSizeMM, ScanDistanceMM float64
+
+	// LogoCoverage is the fraction (0 to 1) of the symbol's module area
+	// that a logo or other overlay will obscure. If zero, the logo/ECC
+	// budget check is skipped.
+	LogoCoverage float64
+
+	// Inverted declares that the symbol is intentionally rendered
+	// light-on-dark (e.g. via PNGOptions.Inverted), rather than having
+	// ended up that way by accident. CheckScannability always warns about
+	// this, separately from the DarkColor/LightColor luminance comparison
+	// above, since an explicitly inverted symbol has no color-swap mistake
+	// for that check to catch.
+	Inverted bool
+}
+
+// minContrastRatio is the WCAG 2.x "AA" text-contrast threshold, reused
+// here as a reasonable rule of thumb for module contrast; it is not a
+// calibrated scanner-hardware specification.
+const minContrastRatio = 4.5
+
+// eccRecoveryFraction is the approximate fraction of a symbol's total
+// codewords that can be wrong (e.g. due to a logo overlay) and still be
+// recovered, at each error correction level. These track the nominal
+// recovery capacities from ISO/IEC 18004 table 9, rounded down slightly
+// for safety margin since a logo also costs penalty-score headroom, not
+// just raw ECC budget.
+var eccRecoveryFraction = map[qrcodegen.QrCodeEcc]float64{
+	qrcodegen.Low:      0.06,
+	qrcodegen.Medium:   0.13,
+	qrcodegen.Quartile: 0.22,
+	qrcodegen.High:     0.27,
+}
+
+// CheckScannability evaluates qr and opts against common real-world causes
+// of unreadable QR Codes, returning a Warning for each problem found. An
+// empty result does not guarantee a symbol will scan (lighting, camera
+// quality, and print defects are out of scope), only that CheckScannability
+// found no issue with the parameters given.
+func CheckScannability(qr *qrcodegen.QrCode, opts ScannabilityOptions) []Warning {
+	var warnings []Warning
+	warn := func(code, format string, a ...any) {
+		warnings = append(warnings, Warning{code, fmt.Sprintf(format, a...)})
+	}
+
+	if opts.Border < 4 {
+		warn("quiet_zone_too_small",
+			"quiet zone is %d modules wide; the spec recommends at least 4", opts.Border)
+	}
+
+	if opts.DarkColor != nil && opts.LightColor != nil {
+		darkLum := relativeLuminance(opts.DarkColor)
+		lightLum := relativeLuminance(opts.LightColor)
+		if darkLum > lightLum {
+			warn("inverted_colors",
+				"the \"dark\" color is lighter than the \"light\" color; most scanners assume dark modules on a light background")
+		}
+		ratio := contrastRatio(darkLum, lightLum)
+		if ratio < minContrastRatio {
+			warn("insufficient_contrast",
+				"contrast ratio between module colors is %.2f:1, below the recommended %.2g:1", ratio, minContrastRatio)
+		}
+	}
+
+	if opts.ModuleSizeMM > 0 && opts.ScanDistanceMM > 0 {
+		// Rule of thumb for camera-based scanners: a module should be at
+		// least 1/10th of the intended scan distance.
+		minModuleSizeMM := opts.ScanDistanceMM / 10
+		if opts.ModuleSizeMM < minModuleSizeMM {
+			warn("module_size_too_small",
+				"module size %.2fmm is below the recommended %.2fmm for a %.0fmm scan distance",
+				opts.ModuleSizeMM, minModuleSizeMM, opts.ScanDistanceMM)
+		}
+	}
+
+	if opts.Inverted {
+		warn("inverted_rendering",
+			"symbol is rendered light-on-dark; many scanners are tuned for dark modules on a light background and may reject this orientation")
+	}
+
+	if opts.LogoCoverage > 0 {
+		budget := eccRecoveryFraction[qr.ErrorCorrectionLevel()]
+		if opts.LogoCoverage > budget {
+			warn("logo_exceeds_ecc_budget",
+				"logo covers %.0f%% of the symbol, exceeding the ~%.0f%% error correction budget at this ECC level",
+				opts.LogoCoverage*100, budget*100)
+		}
+	}
+
+	return warnings
+}
+
+// relativeLuminance computes the WCAG relative luminance of c, in [0, 1].
+func relativeLuminance(c color.Color) float64 {
+	r, g, b, _ := c.RGBA()
+	linearize := func(v uint32) float64 {
+		c := float64(v) / 0xFFFF
+		if c <= 0.03928 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linearize(r) + 0.7152*linearize(g) + 0.0722*linearize(b)
+}
+
+// contrastRatio computes the WCAG contrast ratio between two relative
+// luminances, always >= 1.
+func contrastRatio(lum1, lum2 float64) float64 {
+	if lum1 < lum2 {
+		lum1, lum2 = lum2, lum1
+	}
+	return (lum1 + 0.05) / (lum2 + 0.05)
+}
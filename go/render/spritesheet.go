@@ -0,0 +1,122 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// SVGSpriteSheetOptions lays codes out in a grid for ToSVGSpriteSheet, all
+// measured in SVG user units.
+type SVGSpriteSheetOptions struct {
+	// Cols is the number of cells per row; rows are added as needed to fit
+	// len(codes).
+	Cols int
+
+	// CellSize is the width and height of each cell.
+	CellSize float64
+
+	// Gap is the blank space between adjacent cells.
+	Gap float64
+
+	// Border is the number of light modules of quiet zone drawn around
+	// each symbol.
+	Border int32
+}
+
+// ToSVGSpriteSheet lays codes out across a single SVG document's grid of
+// <use> references into shared <symbol> definitions in <defs>, instead of
+// repeating each symbol's full path data inline. Codes that are identical
+// (same size, same modules) share one <symbol>, which can shrink a page
+// showing dozens of repeated codes (e.g. a seating chart reusing one QR
+// Code per section) far below what one independent <svg> per code costs.
+//
+// Returns an error if codes is empty, or if opts.Cols or opts.CellSize is
+// not positive.
+func ToSVGSpriteSheet(codes []*qrcodegen.QrCode, opts SVGSpriteSheetOptions) (string, error) {
+	if len(codes) == 0 {
+		return "", errors.New("render: no QR Codes given")
+	}
+	if opts.Cols <= 0 {
+		return "", errors.New("render: Cols must be positive")
+	}
+	if opts.CellSize <= 0 {
+		return "", errors.New("render: CellSize must be positive")
+	}
+
+	rows := (len(codes) + opts.Cols - 1) / opts.Cols
+	sheetWidth := float64(opts.Cols)*(opts.CellSize+opts.Gap) - opts.Gap
+	sheetHeight := float64(rows)*(opts.CellSize+opts.Gap) - opts.Gap
+
+	symbolIDs := make(map[string]string) // path data -> symbol id, for dedup
+	var defs, uses strings.Builder
+	for i, qr := range codes {
+		dim := qr.Size() + 2*opts.Border
+		path := svgModulePathData(qr, opts.Border)
+		id, ok := symbolIDs[path]
+		if !ok {
+			id = fmt.Sprintf("qr%d", len(symbolIDs))
+			symbolIDs[path] = id
+			fmt.Fprintf(&defs,
+				"\t\t<symbol id=\"%s\" viewBox=\"0 0 %d %d\"><rect width=\"100%%\" height=\"100%%\" fill=\"#FFFFFF\"/><path d=\"%s\" fill=\"#000000\"/></symbol>\n",
+				id, dim, dim, path)
+		}
+
+		row := i / opts.Cols
+		col := i % opts.Cols
+		x := float64(col) * (opts.CellSize + opts.Gap)
+		y := float64(row) * (opts.CellSize + opts.Gap)
+		fmt.Fprintf(&uses, "\t<use href=\"#%s\" xlink:href=\"#%s\" x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\"/>\n",
+			id, id, fmtNum(x), fmtNum(y), fmtNum(opts.CellSize), fmtNum(opts.CellSize))
+	}
+
+	var b strings.Builder
+	b.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" xmlns:xlink=\"http://www.w3.org/1999/xlink\" version=\"1.1\" viewBox=\"0 0 %s %s\">\n",
+		fmtNum(sheetWidth), fmtNum(sheetHeight))
+	b.WriteString("\t<defs>\n")
+	b.WriteString(defs.String())
+	b.WriteString("\t</defs>\n")
+	b.WriteString(uses.String())
+	b.WriteString("</svg>\n")
+	return b.String(), nil
+}
+
+// svgModulePathData returns the "d" attribute value for a single SVG path
+// drawing every dark module of qr, offset by border. It emits the same
+// "M...h...v1h-...z" box per horizontal run as the core package's own path
+// builder (used by QrCode.ToSvgString), which is unexported, so this
+// version is built from the public GetModule accessor.
+func svgModulePathData(qr *qrcodegen.QrCode, border int32) string {
+	size := qr.Size()
+	var b strings.Builder
+	first := true
+	for y := int32(0); y < size; y++ {
+		for x := int32(0); x < size; {
+			if !qr.GetModule(x, y) {
+				x++
+				continue
+			}
+			runStart := x
+			for x < size && qr.GetModule(x, y) {
+				x++
+			}
+			runLen := x - runStart
+			if !first {
+				b.WriteByte(' ')
+			}
+			first = false
+			fmt.Fprintf(&b, "M%d,%dh%dv1h-%dz", runStart+border, y+border, runLen, runLen)
+		}
+	}
+	return b.String()
+}
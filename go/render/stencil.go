@@ -0,0 +1,197 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"fmt"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// StencilBridges is the set of qr's own module positions (not offset by
+// any border) that AddStencilBridges decided to leave uncut, carved
+// through an otherwise-dark region, to keep an enclosed light island (e.g.
+// a finder pattern's center) attached to the rest of the stencil material.
+type StencilBridges map[qrcodegen.Point]bool
+
+// stencilNeighbors are the 4-connected offsets bridging and island
+// detection move through; a physical stencil's material only holds
+// together through edge-adjacent modules, not diagonal ones.
+var stencilNeighbors = [4]qrcodegen.Point{{X: 1}, {X: -1}, {Y: 1}, {Y: -1}}
+
+// AddStencilBridges finds every light-module region of qr that is fully
+// enclosed by dark modules (does not connect, moving only through light
+// modules, to the border's quiet zone) and carves the shortest path of
+// dark modules connecting each one back out. This is for physically
+// cutting qr as a stencil, with dark modules as open holes and light
+// modules as the material holding the design together: without a bridge,
+// an enclosed island of material (most commonly a finder pattern's light
+// center, surrounded by its dark ring) falls out once cut.
+//
+// border must match the quiet zone width the stencil will be cut with; the
+// outermost ring of the bordered grid is always treated as connected light
+// material.
+func AddStencilBridges(qr *qrcodegen.QrCode, border int32) StencilBridges {
+	size := qr.Size()
+	dim := size + 2*border
+	light := func(p qrcodegen.Point) bool {
+		if p.X < border || p.X >= border+size || p.Y < border || p.Y >= border+size {
+			return true
+		}
+		return !qr.GetModule(p.X-border, p.Y-border)
+	}
+
+	outer := floodFillOuterLight(dim, light)
+
+	visited := make(map[qrcodegen.Point]bool)
+	bridges := make(StencilBridges)
+	for y := int32(0); y < dim; y++ {
+		for x := int32(0); x < dim; x++ {
+			p := qrcodegen.Point{X: x, Y: y}
+			if !light(p) || outer[p] || visited[p] {
+				continue
+			}
+			island := floodFillLightIsland(p, dim, light, visited)
+			for _, bridge := range shortestBridgePath(island, dim, light, outer) {
+				bridges[qrcodegen.Point{X: bridge.X - border, Y: bridge.Y - border}] = true
+			}
+		}
+	}
+	return bridges
+}
+
+// floodFillOuterLight returns every light position reachable from the
+// dim-by-dim grid's own border, moving only through light positions.
+func floodFillOuterLight(dim int32, light func(qrcodegen.Point) bool) map[qrcodegen.Point]bool {
+	outer := make(map[qrcodegen.Point]bool)
+	var queue []qrcodegen.Point
+	visit := func(p qrcodegen.Point) {
+		if !outer[p] {
+			outer[p] = true
+			queue = append(queue, p)
+		}
+	}
+	for x := int32(0); x < dim; x++ {
+		if light(qrcodegen.Point{X: x, Y: 0}) {
+			visit(qrcodegen.Point{X: x, Y: 0})
+		}
+		if light(qrcodegen.Point{X: x, Y: dim - 1}) {
+			visit(qrcodegen.Point{X: x, Y: dim - 1})
+		}
+	}
+	for y := int32(0); y < dim; y++ {
+		if light(qrcodegen.Point{X: 0, Y: y}) {
+			visit(qrcodegen.Point{X: 0, Y: y})
+		}
+		if light(qrcodegen.Point{X: dim - 1, Y: y}) {
+			visit(qrcodegen.Point{X: dim - 1, Y: y})
+		}
+	}
+	for len(queue) > 0 {
+		p := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		for _, d := range stencilNeighbors {
+			n := qrcodegen.Point{X: p.X + d.X, Y: p.Y + d.Y}
+			if n.X < 0 || n.X >= dim || n.Y < 0 || n.Y >= dim || !light(n) {
+				continue
+			}
+			visit(n)
+		}
+	}
+	return outer
+}
+
+// floodFillLightIsland returns every light position reachable from start
+// moving only through light positions, marking each one visited.
+func floodFillLightIsland(start qrcodegen.Point, dim int32, light func(qrcodegen.Point) bool, visited map[qrcodegen.Point]bool) []qrcodegen.Point {
+	var island []qrcodegen.Point
+	queue := []qrcodegen.Point{start}
+	visited[start] = true
+	for len(queue) > 0 {
+		p := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		island = append(island, p)
+		for _, d := range stencilNeighbors {
+			n := qrcodegen.Point{X: p.X + d.X, Y: p.Y + d.Y}
+			if n.X < 0 || n.X >= dim || n.Y < 0 || n.Y >= dim || !light(n) || visited[n] {
+				continue
+			}
+			visited[n] = true
+			queue = append(queue, n)
+		}
+	}
+	return island
+}
+
+// shortestBridgePath returns the shortest chain of dark positions
+// connecting island to any position in outer, found by a breadth-first
+// search that starts at every position in island at once and steps only
+// through dark positions, terminating as soon as it finds a dark position
+// adjacent to outer. Returns nil if island is empty.
+func shortestBridgePath(island []qrcodegen.Point, dim int32, light func(qrcodegen.Point) bool, outer map[qrcodegen.Point]bool) []qrcodegen.Point {
+	if len(island) == 0 {
+		return nil
+	}
+	parent := make(map[qrcodegen.Point]qrcodegen.Point)
+	visited := make(map[qrcodegen.Point]bool)
+	var queue []qrcodegen.Point
+	for _, p := range island {
+		visited[p] = true
+		queue = append(queue, p)
+	}
+
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, d := range stencilNeighbors {
+			n := qrcodegen.Point{X: p.X + d.X, Y: p.Y + d.Y}
+			if n.X < 0 || n.X >= dim || n.Y < 0 || n.Y >= dim || visited[n] {
+				continue
+			}
+			if light(n) {
+				if !outer[n] {
+					continue // part of another island; not a usable exit
+				}
+				// Found the shortest path's exit; reconstruct it back to
+				// (but excluding) the island cell it started from.
+				var path []qrcodegen.Point
+				for cur := p; ; {
+					prev, ok := parent[cur]
+					if !ok {
+						break // cur is the island cell; leave it out
+					}
+					path = append(path, cur)
+					cur = prev
+				}
+				return path
+			}
+			visited[n] = true
+			parent[n] = p
+			queue = append(queue, n)
+		}
+	}
+	return nil // unreachable in a well-formed QR Code symbol
+}
+
+// VerifyStencilBridges checks that bridges modifies no more of qr's own
+// modules than eccRecoveryFraction allows at qr's error correction level,
+// the same budget CheckScannability's LogoCoverage check uses: each bridge
+// cell is printed with the opposite of its symbol-defined color, which a
+// scanner recovers from exactly like a logo overlay.
+//
+// Returns an error if the budget is exceeded.
+func VerifyStencilBridges(qr *qrcodegen.QrCode, bridges StencilBridges) error {
+	size := qr.Size()
+	budget := eccRecoveryFraction[qr.ErrorCorrectionLevel()]
+	coverage := float64(len(bridges)) / float64(size*size)
+	if coverage > budget {
+		return fmt.Errorf("render: stencil bridges alter %.1f%% of modules, exceeding the ~%.0f%% error correction budget at this ECC level",
+			coverage*100, budget*100)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/nayuki/qrcodegen"
+)
+
+// TIFFCompression selects the strip compression scheme used by ToTIFF.
+type TIFFCompression int
+
+const (
+	// TIFFUncompressed stores each row as raw packed bits (TIFF
+	// compression tag value 1). This is what ToTIFF currently supports.
+	TIFFUncompressed TIFFCompression = iota
+
+	// TIFFGroup4 requests CCITT Group 4 (T.6) bilevel compression
+	// (TIFF compression tag value 4), as commonly required by fax and
+	// print RIP workflows. ToTIFF does not implement a Group 4 encoder
+	// (there is no such encoder in the Go standard library, and one is
+	// substantial to write correctly), so requesting it returns an error
+	// instead of silently falling back to an uncompressed strip.
+	TIFFGroup4
+)
+
+// ToTIFF renders qr as a baseline single-strip bilevel TIFF image, with
+// each module occupying a scale-by-scale block of pixels and a border
+// modules wide on every side.
+//
+// Returns an error if scale <= 0, border < 0, the resulting image would
+// exceed maxRasterDimension pixels per side, or compression is
+// TIFFGroup4, which is not implemented; see its documentation.
+func ToTIFF(qr *qrcodegen.QrCode, scale, border int32, compression TIFFCompression) ([]byte, error) {
+	if err := validateScaleBorder(scale, border); err != nil {
+		return nil, err
+	}
+	if compression == TIFFGroup4 {
+		return nil, errors.New("render: CCITT Group 4 TIFF compression is not implemented")
+	}
+
+	size := qr.Size()
+	dim, err := rasterDimension(size, border, scale)
+	if err != nil {
+		return nil, err
+	}
+	bytesPerRow := (dim + 7) / 8
+	pixels := make([]byte, bytesPerRow*dim) // 1 = white, 0 = black (BlackIsZero photometric)
+	for i := range pixels {
+		pixels[i] = 0xFF
+	}
+	for y := int32(0); y < size; y++ {
+		for x := int32(0); x < size; x++ {
+			if !qr.GetModule(x, y) {
+				continue
+			}
+			baseX := int((x + border) * scale)
+			baseY := int((y + border) * scale)
+			for dy := 0; dy < int(scale); dy++ {
+				row := pixels[(baseY+dy)*bytesPerRow : (baseY+dy+1)*bytesPerRow]
+				for dx := 0; dx < int(scale); dx++ {
+					px := baseX + dx
+					row[px/8] &^= 1 << uint(7-px%8)
+				}
+			}
+		}
+	}
+
+	return encodeBilevelTIFF(dim, dim, pixels), nil
+}
+
+// encodeBilevelTIFF writes a minimal, baseline little-endian TIFF file
+// holding one uncompressed 1-bit-per-pixel strip.
+func encodeBilevelTIFF(width, height int, pixels []byte) []byte {
+	const headerLen = 8
+	const numEntries = 8
+	ifdLen := 2 + numEntries*12 + 4
+	pixelsOffset := headerLen + ifdLen
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, [4]byte{'I', 'I', 42, 0})
+	binary.Write(&buf, binary.LittleEndian, uint32(headerLen)) // offset of the one and only IFD
+
+	type entry struct {
+		tag, typ uint16
+		count    uint32
+		value    uint32
+	}
+	entries := []entry{
+		{256, 3, 1, uint32(width)},        // ImageWidth, SHORT
+		{257, 3, 1, uint32(height)},       // ImageLength, SHORT
+		{258, 3, 1, 1},                    // BitsPerSample, SHORT
+		{259, 3, 1, 1},                    // Compression = 1 (none)
+		{262, 3, 1, 1},                    // PhotometricInterpretation = 1 (BlackIsZero)
+		{273, 4, 1, uint32(pixelsOffset)}, // StripOffsets, LONG
+		{278, 3, 1, uint32(height)},       // RowsPerStrip
+		{279, 4, 1, uint32(len(pixels))},  // StripByteCounts, LONG
+	}
+	binary.Write(&buf, binary.LittleEndian, uint16(len(entries)))
+	for _, e := range entries {
+		binary.Write(&buf, binary.LittleEndian, e.tag)
+		binary.Write(&buf, binary.LittleEndian, e.typ)
+		binary.Write(&buf, binary.LittleEndian, e.count)
+		binary.Write(&buf, binary.LittleEndian, e.value)
+	}
+	binary.Write(&buf, binary.LittleEndian, uint32(0)) // no next IFD
+
+	buf.Write(pixels)
+	return buf.Bytes()
+}
@@ -0,0 +1,28 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package rs
+
+// Correct attempts to find and fix errors in codeword, a received polynomial
+// whose last eccLen coefficients (highest to lowest power, like
+// ComputeRemainder's output) are meant to be zero syndromes against the
+// generator roots alpha^0 .. alpha^(eccLen-1), i.e. a codeword produced by
+// appending ComputeRemainder's result to the original data. It can correct
+// up to eccLen/2 byte errors at unknown positions.
+//
+// Returns a corrected copy of codeword, or an error if the number of errors
+// exceeds what eccLen can guarantee to correct (the classic bounded-distance
+// decoding limit; a malformed-enough codeword can in rare cases decode to an
+// incorrect codeword instead of being flagged, which is an inherent
+// limitation of Reed-Solomon decoding, not a bug in this implementation).
+func Correct(codeword []byte, eccLen int) ([]byte, error) {
+	corrected, err := qrField.Correct(toSymbols(codeword), eccLen)
+	if err != nil {
+		return nil, err
+	}
+	return toBytes(corrected), nil
+}
@@ -0,0 +1,358 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package rs
+
+import "fmt"
+
+// Field is a Galois field GF(2^size) with an arbitrary primitive reduction
+// polynomial, generalizing this package's original hardcoded GF(2^8)/0x11D
+// arithmetic so that other byte- or symbol-oriented Reed-Solomon variants
+// (such as the different field parameters used by Micro QR, rMQR, and other
+// barcode formats) can share this one audited implementation.
+//
+// Elements are represented as the low size bits of a uint16, which supports
+// symbol sizes up to 16 bits; GF(2^8) (size 8) covers ordinary QR Code.
+//
+// The zero value is not usable; construct one with NewField or
+// NewFieldChecked.
+type Field struct {
+	size     int
+	elements int // 1 << size
+	expTable []uint16
+	logTable []int
+}
+
+// NewField is like NewFieldChecked, but panics instead of returning an
+// error.
+func NewField(size int, poly uint32) *Field {
+	f, err := NewFieldChecked(size, poly)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// NewFieldChecked returns the Galois field GF(2^size) reduced by poly, a
+// degree-size polynomial given as a bitmask (bit i set means the x^i term is
+// present; the x^size term, implicitly 1, is not included in the mask's
+// valid range check but is implied). For ordinary QR Code, size is 8 and
+// poly is 0x11D.
+//
+// Returns an error if size is outside [1, 16], poly isn't a degree-size
+// polynomial, or poly isn't primitive over GF(2^size) (i.e. x does not
+// generate every nonzero element under repeated multiplication).
+func NewFieldChecked(size int, poly uint32) (*Field, error) {
+	if size < 1 || size > 16 {
+		return nil, fmt.Errorf("rs: field size %d bits is outside the supported range [1, 16]", size)
+	}
+	elements := 1 << uint(size)
+	if poly&uint32(elements) == 0 || poly >= uint32(elements)<<1 {
+		return nil, fmt.Errorf("rs: 0x%X is not a degree-%d polynomial", poly, size)
+	}
+
+	expTable := make([]uint16, elements-1)
+	logTable := make([]int, elements)
+	for i := range logTable {
+		logTable[i] = -1
+	}
+	value := uint32(1)
+	for i := 0; i < elements-1; i++ {
+		if logTable[value] != -1 {
+			return nil, fmt.Errorf("rs: 0x%X is not primitive over GF(2^%d)", poly, size)
+		}
+		expTable[i] = uint16(value)
+		logTable[value] = i
+		value <<= 1
+		if value&uint32(elements) != 0 {
+			value ^= poly
+		}
+	}
+	if value != 1 {
+		return nil, fmt.Errorf("rs: 0x%X is not primitive over GF(2^%d)", poly, size)
+	}
+	return &Field{size: size, elements: elements, expTable: expTable, logTable: logTable}, nil
+}
+
+// Multiply returns the product of x and y in this field. All values with
+// their top bits (size and above) clear are valid field elements.
+func (f *Field) Multiply(x, y uint16) uint16 {
+	if x == 0 || y == 0 {
+		return 0
+	}
+	sum := f.logTable[x] + f.logTable[y]
+	if sum >= f.elements-1 {
+		sum -= f.elements - 1
+	}
+	return f.expTable[sum]
+}
+
+// Power returns x raised to the n'th power (n >= 0) in this field.
+func (f *Field) Power(x uint16, n int) uint16 {
+	if n == 0 {
+		return 1
+	}
+	if x == 0 {
+		return 0
+	}
+	order := f.elements - 1
+	e := (f.logTable[x] * (n % order)) % order
+	if e < 0 {
+		e += order
+	}
+	return f.expTable[e]
+}
+
+// Inverse returns the multiplicative inverse of x.
+//
+// Panics if x is zero, which has no multiplicative inverse.
+func (f *Field) Inverse(x uint16) uint16 {
+	if x == 0 {
+		panic("rs: zero has no multiplicative inverse")
+	}
+	return f.Power(x, f.elements-2)
+}
+
+// ComputeDivisor returns a Reed-Solomon ECC generator polynomial for the
+// given degree over this field, which is the number of error correction
+// codewords it will produce via ComputeRemainder.
+//
+// Panics if degree < 1 or degree > f's number of nonzero elements.
+func (f *Field) ComputeDivisor(degree int) []uint16 {
+	if degree < 1 || degree > f.elements-1 {
+		panic("degree out of range")
+	}
+	// Polynomial coefficients are stored from highest to lowest power,
+	// excluding the leading term which is always 1.
+	result := make([]uint16, degree)
+	result[degree-1] = 1 // Start off with the monomial x^0
+
+	// Compute the product polynomial (x - r^0) * (x - r^1) * ... * (x - r^{degree-1}),
+	// and drop the highest monomial term which is always 1x^degree.
+	// r = 2, which is a generator element of this field by construction.
+	root := uint16(1)
+	for i := 0; i < degree; i++ {
+		for j := 0; j < degree; j++ {
+			result[j] = f.Multiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = f.Multiply(root, 2)
+	}
+	return result
+}
+
+// ComputeRemainder returns the Reed-Solomon error correction codeword for
+// the given data polynomial, divided by the given divisor polynomial (as
+// returned by ComputeDivisor). The result has the same length as divisor.
+func (f *Field) ComputeRemainder(data []uint16, divisor []uint16) []uint16 {
+	result := make([]uint16, len(divisor))
+	for _, b := range data { // Polynomial division
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, y := range divisor {
+			result[i] ^= f.Multiply(y, factor)
+		}
+	}
+	return result
+}
+
+// Correct attempts to find and fix errors in codeword, a received polynomial
+// whose last eccLen coefficients (highest to lowest power, like
+// ComputeRemainder's output) are meant to be zero syndromes against the
+// generator roots 2^0 .. 2^(eccLen-1), i.e. a codeword produced by appending
+// ComputeRemainder's result to the original data. It can correct up to
+// eccLen/2 symbol errors at unknown positions.
+//
+// Returns a corrected copy of codeword, or an error if the number of errors
+// exceeds what eccLen can guarantee to correct (the classic bounded-distance
+// decoding limit; a malformed-enough codeword can in rare cases decode to an
+// incorrect codeword instead of being flagged, which is an inherent
+// limitation of Reed-Solomon decoding, not a bug in this implementation).
+func (f *Field) Correct(codeword []uint16, eccLen int) ([]uint16, error) {
+	syndromes := f.computeSyndromes(codeword, eccLen)
+	if f.allZero(syndromes) {
+		return append([]uint16{}, codeword...), nil
+	}
+
+	locator := f.berlekampMassey(syndromes)
+	numErrors := len(locator) - 1
+	if numErrors <= 0 || numErrors > eccLen/2 {
+		return nil, fmt.Errorf("rs: too many errors to correct")
+	}
+
+	positions, ok := f.chienSearch(locator, len(codeword))
+	if !ok || len(positions) != numErrors {
+		return nil, fmt.Errorf("rs: too many errors to correct")
+	}
+
+	evaluator := f.errorEvaluator(syndromes, locator, eccLen)
+	derivative := f.formalDerivative(locator)
+
+	result := append([]uint16{}, codeword...)
+	for _, p := range positions {
+		x := f.Power(2, p) // 2^p, the error location value
+		xInv := f.Inverse(x)
+		num := f.evalAscending(evaluator, xInv)
+		den := f.evalAscending(derivative, xInv)
+		if den == 0 {
+			return nil, fmt.Errorf("rs: too many errors to correct")
+		}
+		// The extra factor of x (rather than the textbook Forney formula's
+		// implicit 1) compensates for this package's syndromes starting at
+		// the generator's 2^0 root instead of the narrow-sense 2^1.
+		magnitude := f.Multiply(x, f.Multiply(num, f.Inverse(den)))
+		idx := len(codeword) - 1 - p
+		result[idx] ^= magnitude
+	}
+
+	if !f.allZero(f.computeSyndromes(result, eccLen)) {
+		return nil, fmt.Errorf("rs: too many errors to correct")
+	}
+	return result, nil
+}
+
+func (f *Field) allZero(syndromes []uint16) bool {
+	for _, s := range syndromes {
+		if s != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// computeSyndromes returns S[j] = evalPoly(codeword, 2^j) for j in
+// [0, eccLen), i.e. the codeword evaluated at each root of the generator
+// polynomial that ComputeDivisor(eccLen) builds.
+func (f *Field) computeSyndromes(codeword []uint16, eccLen int) []uint16 {
+	syndromes := make([]uint16, eccLen)
+	for j := range syndromes {
+		syndromes[j] = f.evalPoly(codeword, f.Power(2, j))
+	}
+	return syndromes
+}
+
+// evalPoly evaluates poly (coefficients from highest power to lowest, like
+// ComputeDivisor's convention) at x, via Horner's method.
+func (f *Field) evalPoly(poly []uint16, x uint16) uint16 {
+	var result uint16
+	for _, c := range poly {
+		result = f.Multiply(result, x) ^ c
+	}
+	return result
+}
+
+// berlekampMassey finds the shortest linear feedback shift register that
+// generates syndromes, i.e. the error locator polynomial, returned as
+// coefficients from lowest power to highest (locator[0] is always 1).
+func (f *Field) berlekampMassey(syndromes []uint16) []uint16 {
+	c := []uint16{1} // Current locator polynomial candidate
+	b := []uint16{1} // Locator polynomial before the last length change
+	l := 0           // Current linear complexity (number of presumed errors)
+	m := 1           // Number of iterations since b was last updated
+	bCoeff := uint16(1)
+
+	for n := 0; n < len(syndromes); n++ {
+		delta := syndromes[n]
+		for i := 1; i <= l; i++ {
+			if i < len(c) {
+				delta ^= f.Multiply(c[i], syndromes[n-i])
+			}
+		}
+		if delta == 0 {
+			m++
+			continue
+		}
+		t := append([]uint16{}, c...)
+		coef := f.Multiply(delta, f.Inverse(bCoeff))
+		needed := m + len(b)
+		if needed > len(c) {
+			c = append(c, make([]uint16, needed-len(c))...)
+		}
+		for i, bi := range b {
+			c[i+m] ^= f.Multiply(coef, bi)
+		}
+		if 2*l <= n {
+			l = n + 1 - l
+			b = t
+			bCoeff = delta
+			m = 1
+		} else {
+			m++
+		}
+	}
+	return c[:l+1]
+}
+
+// chienSearch returns every power p in [0, n) such that 2^-p is a root of
+// locator (coefficients lowest to highest power), i.e. every candidate
+// error position. ok is false if n exceeds the field's number of nonzero
+// elements, which would make positions ambiguous.
+func (f *Field) chienSearch(locator []uint16, n int) (positions []int, ok bool) {
+	if n > f.elements-1 {
+		return nil, false
+	}
+	for p := 0; p < n; p++ {
+		xInv := f.Inverse(f.Power(2, p))
+		if f.evalAscending(locator, xInv) == 0 {
+			positions = append(positions, p)
+		}
+	}
+	return positions, true
+}
+
+// evalAscending evaluates poly (coefficients from lowest power to highest)
+// at x.
+func (f *Field) evalAscending(poly []uint16, x uint16) uint16 {
+	var result uint16
+	var xPow uint16 = 1
+	for _, c := range poly {
+		result ^= f.Multiply(c, xPow)
+		xPow = f.Multiply(xPow, x)
+	}
+	return result
+}
+
+// errorEvaluator computes Omega(x) = (S(x) * Lambda(x)) mod x^eccLen, where
+// S and locator are both given lowest power first, and the result is also
+// lowest power first.
+func (f *Field) errorEvaluator(syndromes, locator []uint16, eccLen int) []uint16 {
+	degree := eccLen
+	if d := len(syndromes) + len(locator) - 1; d < degree {
+		degree = d
+	}
+	result := make([]uint16, degree)
+	for i, s := range syndromes {
+		for j, l := range locator {
+			if i+j >= eccLen {
+				break
+			}
+			if i+j < len(result) {
+				result[i+j] ^= f.Multiply(s, l)
+			}
+		}
+	}
+	return result
+}
+
+// formalDerivative returns the formal derivative of poly (lowest power
+// first).
+func (f *Field) formalDerivative(poly []uint16) []uint16 {
+	if len(poly) <= 1 {
+		return []uint16{0}
+	}
+	// Over GF(2^n), d/dx(x^i) is x^(i-1) for odd i and 0 for even i, so each
+	// odd-power coefficient lands at the even power one below it; the even
+	// powers in between are zero.
+	result := make([]uint16, len(poly)-1)
+	for i := 1; i < len(poly); i += 2 {
+		result[i-1] = poly[i]
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Package rs implements Reed-Solomon error correction coding over Galois
+// fields of the form GF(2^size), via the generic Field type. The
+// package-level functions below are a byte-oriented convenience wrapper
+// fixed to GF(2^8) with reduction polynomial 0x11D, as used by QR Code;
+// construct a Field directly (with NewField or NewFieldChecked) for other
+// parameters, such as the ones other barcode formats use.
+package rs
+
+// qrField is the GF(2^8)/0x11D field QR Code's Reed-Solomon coding uses,
+// backing this package's byte-oriented convenience functions.
+var qrField = NewField(8, 0x11D)
+
+// ComputeDivisor returns a Reed-Solomon ECC generator polynomial for the
+// given degree, which is the number of error correction codewords it will
+// produce via ComputeRemainder. This could be implemented as a lookup table
+// over all possible parameter values, instead of as an algorithm.
+//
+// Panics if degree < 1 or degree > 255.
+func ComputeDivisor(degree int) []byte {
+	return toBytes(qrField.ComputeDivisor(degree))
+}
+
+// ComputeRemainder returns the Reed-Solomon error correction codeword for
+// the given data polynomial, divided by the given divisor polynomial (as
+// returned by ComputeDivisor). The result has the same length as divisor.
+func ComputeRemainder(data []byte, divisor []byte) []byte {
+	return toBytes(qrField.ComputeRemainder(toSymbols(data), toSymbols(divisor)))
+}
+
+// Multiply returns the product of the two given field elements modulo
+// GF(2^8/0x11D). All byte values are valid field elements.
+//
+// Field elements are not integers: addition and subtraction are both XOR
+// (^), and a field element is its own additive inverse, so there is no
+// separate "negate" operation analogous to two's complement wrapping
+// negation for this function's inputs or result.
+func Multiply(x, y byte) byte {
+	return byte(qrField.Multiply(uint16(x), uint16(y)))
+}
+
+// toSymbols widens a byte slice into the uint16 symbol slice Field methods
+// operate on.
+func toSymbols(data []byte) []uint16 {
+	result := make([]uint16, len(data))
+	for i, b := range data {
+		result[i] = uint16(b)
+	}
+	return result
+}
+
+// toBytes narrows a uint16 symbol slice back into bytes, the inverse of
+// toSymbols; safe for GF(2^8), whose symbols always fit in a byte.
+func toBytes(data []uint16) []byte {
+	result := make([]byte, len(data))
+	for i, s := range data {
+		result[i] = byte(s)
+	}
+	return result
+}
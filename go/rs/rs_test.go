@@ -0,0 +1,106 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package rs
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+// TestMultiplyCommutative checks that Multiply is commutative, as required
+// of a field's multiplication.
+func TestMultiplyCommutative(t *testing.T) {
+	f := func(x, y byte) bool {
+		return Multiply(x, y) == Multiply(y, x)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestMultiplyIdentityAndZero checks that 1 is Multiply's identity element
+// and 0 is its absorbing element, for every field element.
+func TestMultiplyIdentityAndZero(t *testing.T) {
+	f := func(x byte) bool {
+		return Multiply(x, 1) == x && Multiply(x, 0) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestSelfXorIsZero checks that every field element is its own additive
+// inverse: addition and subtraction in GF(2^8) are both XOR, so x^x is
+// always the additive identity, 0. This is the property that rules out a
+// separate two's-complement-style negation operation for this field.
+func TestSelfXorIsZero(t *testing.T) {
+	f := func(x byte) bool {
+		return x^x == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestComputeRemainderLength checks that ComputeRemainder always returns a
+// result exactly as long as the divisor, regardless of the data's length,
+// matching its documented contract.
+func TestComputeRemainderLength(t *testing.T) {
+	f := func(data []byte, degree uint8) bool {
+		degree = degree%250 + 1 // degree must be in [1, 255]
+		divisor := ComputeDivisor(int(degree))
+		remainder := ComputeRemainder(data, divisor)
+		return len(remainder) == len(divisor)
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 200}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestEncodeThenCorrectRoundTrips checks that a codeword built from
+// ComputeRemainder, then corrupted with up to eccLen/2 symbol errors,
+// always corrects back to the original via the underlying Field's Correct
+// method: the actual round trip addEccAndInterleave's callers rely on.
+func TestEncodeThenCorrectRoundTrips(t *testing.T) {
+	f := func(data []byte, eccLenSeed uint8, seed uint32) bool {
+		if len(data) == 0 || len(data) > 200 {
+			return true
+		}
+		eccLen := int(eccLenSeed)%20 + 2
+		divisor := ComputeDivisor(eccLen)
+		ecc := ComputeRemainder(data, divisor)
+
+		codeword := append(append([]byte{}, data...), ecc...)
+		original := append([]byte{}, codeword...)
+
+		// Corrupt up to eccLen/2 distinct positions.
+		numErrors := eccLen / 2
+		state := seed | 1
+		corrupted := make(map[int]bool)
+		for len(corrupted) < numErrors {
+			state = state*1664525 + 1013904223
+			pos := int(state>>8) % len(codeword)
+			if corrupted[pos] {
+				continue
+			}
+			corrupted[pos] = true
+			state = state*1664525 + 1013904223
+			delta := byte(state>>16) | 1 // never zero, so this really changes the symbol
+			codeword[pos] ^= delta
+		}
+
+		corrected, err := qrField.Correct(toSymbols(codeword), eccLen)
+		if err != nil {
+			return false
+		}
+		return string(toBytes(corrected)) == string(original)
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 200}); err != nil {
+		t.Error(err)
+	}
+}
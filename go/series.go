@@ -0,0 +1,68 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SeriesOptions controls GenerateSeries beyond the templated payload.
+type SeriesOptions struct {
+	// ErrorCorrectionLevel is passed to EncodeText for every symbol in the
+	// series.
+	ErrorCorrectionLevel QrCodeEcc
+
+	// MinDigits, if greater than the number of digits in a given n, left-pads
+	// that n with zeros to reach it.
+	MinDigits int
+}
+
+// SeriesEntry pairs one symbol from GenerateSeries with the serial number
+// and payload it was generated from, for building a print-run manifest
+// (e.g. a CSV mapping asset ID to symbol) alongside the symbols themselves.
+type SeriesEntry struct {
+	N       int
+	Payload string
+	Code    *QrCode
+}
+
+// GenerateSeries encodes count sequential symbols from template, substituting
+// its "{n}" placeholder with start, start+1, ..., start+count-1 (each
+// zero-padded to opts.MinDigits digits), for the common operations workflow
+// of printing a batch of QR Codes for serialized assets, e.g. template
+// "https://a.example/asset/{n}".
+//
+// Returns an error if template does not contain "{n}", if count is not
+// positive, or if any substituted payload fails to encode (see EncodeText).
+func GenerateSeries(template string, start, count int, opts SeriesOptions) ([]SeriesEntry, error) {
+	if !strings.Contains(template, "{n}") {
+		return nil, fmt.Errorf("qrcodegen: template %q has no {n} placeholder", template)
+	}
+	if count <= 0 {
+		return nil, fmt.Errorf("qrcodegen: count must be positive, got %d", count)
+	}
+
+	result := make([]SeriesEntry, count)
+	for i := 0; i < count; i++ {
+		n := start + i
+		numStr := strconv.Itoa(n)
+		if pad := opts.MinDigits - len(numStr); pad > 0 {
+			numStr = strings.Repeat("0", pad) + numStr
+		}
+		payload := strings.ReplaceAll(template, "{n}", numStr)
+
+		qr, err := EncodeText(payload, opts.ErrorCorrectionLevel)
+		if err != nil {
+			return nil, fmt.Errorf("qrcodegen: n=%d: %w", n, err)
+		}
+		result[i] = SeriesEntry{N: n, Payload: payload, Code: qr}
+	}
+	return result, nil
+}
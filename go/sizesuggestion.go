@@ -0,0 +1,65 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import "github.com/nayuki/qrcodegen/qrsegment"
+
+// SizeSuggestion describes one feasible (version, error correction level)
+// combination for a particular payload, as returned by SuggestSizes.
+type SizeSuggestion struct {
+	// Version is a QR Code version that fits the payload at
+	// ErrorCorrectionLevel.
+	Version Version
+
+	// ErrorCorrectionLevel is the error correction level evaluated at
+	// Version.
+	ErrorCorrectionLevel QrCodeEcc
+
+	// SizeModules is the symbol's side length in modules, excluding any
+	// border (i.e. Version*4 + 17).
+	SizeModules int32
+}
+
+// PhysicalSizeMM returns the symbol's printed side length in millimetres,
+// given the number of border modules and the print resolution in dots per
+// inch, assuming each module is printed as exactly one dot.
+func (s SizeSuggestion) PhysicalSizeMM(border int32, dpi float64) float64 {
+	totalModules := float64(s.SizeModules + border*2)
+	const mmPerInch = 25.4
+	return totalModules / dpi * mmPerInch
+}
+
+// SuggestSizes returns every (version, error correction level)
+// combination within [minVersion, maxVersion] at which segs fits,
+// ordered first by ascending version and then by ascending error
+// correction level, for a product owner to pick a print size from.
+//
+// Unlike EncodeSegmentsAdvanced, which returns only the smallest fitting
+// version at a single requested level, SuggestSizes reports the full
+// matrix so a caller can compare trade-offs (e.g. a smaller code at a
+// lower ECC level versus a larger one that tolerates more damage) before
+// committing to a choice.
+func SuggestSizes(segs []*qrsegment.QrSegment, minVersion, maxVersion Version) []SizeSuggestion {
+	var result []SizeSuggestion
+	for version := minVersion; version <= maxVersion; version++ {
+		dataUsed, ok := qrsegment.GetTotalBits(segs, version)
+		if !ok {
+			continue
+		}
+		for _, ecl := range []QrCodeEcc{Low, Medium, Quartile, High} {
+			if dataUsed <= getNumDataCodewords(version, ecl)*8 {
+				result = append(result, SizeSuggestion{
+					Version:              version,
+					ErrorCorrectionLevel: ecl,
+					SizeModules:          int32(version)*4 + 17,
+				})
+			}
+		}
+	}
+	return result
+}
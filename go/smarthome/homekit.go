@@ -0,0 +1,81 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package smarthome
+
+import "fmt"
+
+// HomeKitFlag is a bit of HomeKitPayload.Flags, advertising which transport
+// an accessory supports.
+type HomeKitFlag uint8
+
+const (
+	HomeKitFlagBLE HomeKitFlag = 1 << 1
+	HomeKitFlagIP  HomeKitFlag = 1 << 2
+)
+
+// homeKitBase36Charset is the 36-character alphabet (digits then uppercase
+// letters) HomeKit setup payloads are encoded with.
+const homeKitBase36Charset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// HomeKitPayload holds the fields packed into an Apple HomeKit Accessory
+// Protocol "setup payload": a 46-bit value (as reverse-engineered and
+// implemented by the open-source HomeKit accessory community; Apple does
+// not publish the HAP specification) identifying the accessory's category,
+// supported transports, and setup code, base36-encoded behind an
+// "X-HM://" prefix.
+type HomeKitPayload struct {
+	// Category is the accessory category identifier HomeKit assigns (e.g.
+	// 2 for a bridge, 5 for a lightbulb).
+	Category uint8
+
+	// Flags is a bitwise-OR of HomeKitFlag values naming the accessory's
+	// supported transports.
+	Flags HomeKitFlag
+
+	// SetupCode is the 8-digit numeric pairing code, e.g. 12345678 for the
+	// code printed as "123-45-678". Must be in 0-99999999.
+	SetupCode uint32
+
+	// SetupID is an optional 4-character identifier HomeKit controllers use
+	// to disambiguate this accessory's payload from others nearby sharing
+	// the same setup code. Empty omits it.
+	SetupID string
+}
+
+// Encode returns p as a HomeKit setup URI: "X-HM://" followed by the
+// base36 encoding of its packed fields (zero-padded to 9 characters), with
+// SetupID appended verbatim if non-empty.
+//
+// Returns an error if SetupCode is out of range or SetupID is set but is
+// not exactly 4 characters.
+func (p HomeKitPayload) Encode() (string, error) {
+	if p.SetupCode > 99999999 {
+		return "", fmt.Errorf("smarthome: setup code %d exceeds 8 decimal digits", p.SetupCode)
+	}
+	if p.SetupID != "" && len(p.SetupID) != 4 {
+		return "", fmt.Errorf("smarthome: setup ID %q must be exactly 4 characters", p.SetupID)
+	}
+
+	// Fields are packed MSB-first: version (always 0) in the top 3 bits,
+	// then reserved (always 0), category, flags, and finally the setup
+	// code in the low 27 bits.
+	var value uint64
+	value = 0            // version
+	value = value<<4 | 0 // reserved
+	value = value<<8 | uint64(p.Category)
+	value = value<<4 | uint64(p.Flags)
+	value = value<<27 | uint64(p.SetupCode)
+
+	digits := make([]byte, 9)
+	for i := len(digits) - 1; i >= 0; i-- {
+		digits[i] = homeKitBase36Charset[value%36]
+		value /= 36
+	}
+
+	return "X-HM://" + string(digits) + p.SetupID, nil
+}
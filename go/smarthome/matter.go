@@ -0,0 +1,168 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Package smarthome builds the proprietary pairing-code payloads that two
+// smart-home onboarding schemes expect inside a QR Code: Matter's "MT:"
+// base38 TLV payload, and Apple HomeKit's "X-HM://" setup URI. This package
+// only assembles the payload text; encoding that text into a symbol is the
+// caller's job, e.g. qrcodegen.EncodeText(payload, qrcodegen.Medium).
+package smarthome
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// DiscoveryCapability is a bit of MatterPayload.DiscoveryCapabilities,
+// advertising which transport the commissionee can be discovered over.
+type DiscoveryCapability uint8
+
+const (
+	DiscoverySoftAP    DiscoveryCapability = 1 << 0
+	DiscoveryBLE       DiscoveryCapability = 1 << 1
+	DiscoveryOnNetwork DiscoveryCapability = 1 << 2
+)
+
+// CustomFlow is MatterPayload.CustomFlow, naming the commissioning flow a
+// scanner should follow after reading the payload.
+type CustomFlow uint8
+
+const (
+	FlowStandard           CustomFlow = 0
+	FlowUserActionRequired CustomFlow = 1
+	FlowCustom             CustomFlow = 2
+)
+
+// matterBase38Charset is the 38-character alphabet the Matter specification
+// assigns to its base38 encoding: the 10 digits, the 26 uppercase letters,
+// '-', and '.'.
+const matterBase38Charset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ-."
+
+// matterInvalidPasscodes lists the Passcode values the Matter specification
+// singles out as forbidden because they are not plausibly random (e.g. a
+// repeated digit, or an ascending/descending run), even though they fall
+// inside the otherwise-valid range.
+var matterInvalidPasscodes = map[uint32]bool{
+	0:        true,
+	11111111: true,
+	22222222: true,
+	33333333: true,
+	44444444: true,
+	55555555: true,
+	66666666: true,
+	77777777: true,
+	88888888: true,
+	99999999: true,
+	12345678: true,
+	87654321: true,
+}
+
+// MatterPayload holds the fields the Matter specification's "onboarding
+// payload" packs into a QR Code: 88 bits of device-identity and
+// commissioning data, base38-encoded behind an "MT:" prefix.
+//
+// This covers the mandatory packed-binary fields only; the specification's
+// optional vendor/product TLV extension (appended after the packed bits for
+// richer, manufacturer-specific onboarding data) is out of scope.
+type MatterPayload struct {
+	// VendorID and ProductID identify the device, each a 16-bit value
+	// assigned by the Connectivity Standards Alliance and the vendor
+	// respectively.
+	VendorID  uint16
+	ProductID uint16
+
+	// CustomFlow names the commissioning flow. Zero value is FlowStandard.
+	CustomFlow CustomFlow
+
+	// DiscoveryCapabilities is a bitwise-OR of DiscoveryCapability values
+	// naming which transports the commissionee can be discovered over.
+	DiscoveryCapabilities DiscoveryCapability
+
+	// Discriminator is a 12-bit value (0-4095) a commissionee advertises to
+	// disambiguate itself from other devices awaiting commissioning on the
+	// same network.
+	Discriminator uint16
+
+	// Passcode is the 27-bit setup PIN code (1-99999998, excluding a short
+	// list of specification-forbidden trivial values) a commissioner proves
+	// knowledge of to pair.
+	Passcode uint32
+}
+
+// Encode returns p as a Matter onboarding payload, "MT:" followed by the
+// base38 encoding of its packed binary fields.
+//
+// Returns an error if Discriminator, Passcode, or CustomFlow is out of
+// range.
+func (p MatterPayload) Encode() (string, error) {
+	if p.Discriminator > 0xFFF {
+		return "", fmt.Errorf("smarthome: discriminator %d exceeds the 12-bit range", p.Discriminator)
+	}
+	if p.CustomFlow > FlowCustom {
+		return "", fmt.Errorf("smarthome: custom flow %d is not a recognized value", p.CustomFlow)
+	}
+	if p.Passcode == 0 || p.Passcode > 99999998 {
+		return "", fmt.Errorf("smarthome: passcode %d is outside the valid 1-99999998 range", p.Passcode)
+	}
+	if matterInvalidPasscodes[p.Passcode] {
+		return "", fmt.Errorf("smarthome: passcode %d is on the specification's forbidden list of trivial codes", p.Passcode)
+	}
+
+	// Fields are packed LSB-first: version occupies the lowest 3 bits, each
+	// subsequent field sits immediately above the previous one.
+	val := new(big.Int)
+	val.Or(val, big.NewInt(0)) // version, always 0
+	or := func(field uint64, shift uint) {
+		val.Or(val, new(big.Int).Lsh(new(big.Int).SetUint64(field), shift))
+	}
+	or(uint64(p.VendorID), 3)
+	or(uint64(p.ProductID), 19)
+	or(uint64(p.CustomFlow), 35)
+	or(uint64(p.DiscoveryCapabilities), 37)
+	or(uint64(p.Discriminator), 45)
+	or(uint64(p.Passcode), 57)
+	// Bits 84-87 are padding, left at 0.
+
+	beBytes := make([]byte, 11)
+	val.FillBytes(beBytes)
+	packed := make([]byte, 11)
+	for i, b := range beBytes {
+		packed[len(beBytes)-1-i] = b
+	}
+
+	return "MT:" + matterBase38Encode(packed), nil
+}
+
+// matterBase38Encode encodes data per the Matter specification's base38
+// scheme: each run of 3 input bytes becomes 5 output characters, a final 2
+// bytes become 4 characters, and a final 1 byte becomes 2 characters.
+func matterBase38Encode(data []byte) string {
+	var out []byte
+	i := 0
+	for ; i+3 <= len(data); i += 3 {
+		value := uint32(data[i]) | uint32(data[i+1])<<8 | uint32(data[i+2])<<16
+		out = appendBase38(out, value, 5)
+	}
+	switch len(data) - i {
+	case 2:
+		value := uint32(data[i]) | uint32(data[i+1])<<8
+		out = appendBase38(out, value, 4)
+	case 1:
+		out = appendBase38(out, uint32(data[i]), 2)
+	}
+	return string(out)
+}
+
+// appendBase38 appends the base38 encoding of value, exactly digits
+// characters wide, to out.
+func appendBase38(out []byte, value uint32, digits int) []byte {
+	for i := 0; i < digits; i++ {
+		out = append(out, matterBase38Charset[value%38])
+		value /= 38
+	}
+	return out
+}
@@ -0,0 +1,92 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"fmt"
+
+	"github.com/nayuki/qrcodegen/qrsegment"
+)
+
+// maxStructuredAppendSymbols is the largest sequence length that the
+// Structured Append header's 4-bit total field (storing total-1) can
+// represent.
+const maxStructuredAppendSymbols = 16
+
+// EncodeTextAcross splits the given Unicode text across as many QR Code
+// symbols as needed to fit maxVersionPerSymbol, using the Structured
+// Append mechanism: each symbol is prefixed with a header recording its
+// index, the total symbol count, and a parity byte computed by XORing
+// every byte of text together, so that a reader can verify the symbols
+// belong together and reassemble them in order.
+//
+// Splitting occurs at character (rune) boundaries: each symbol holds the
+// longest prefix of the remaining text that fits, encoded on its own with
+// qrsegment.MakeSegments. Split points are not aligned to the segment
+// boundaries of the text as a whole.
+//
+// Returns an error if a single character's segment cannot fit in a symbol
+// even alone, or if the text requires more than 16 symbols.
+func EncodeTextAcross(text string, ecl QrCodeEcc, maxVersionPerSymbol Version) ([]*QrCode, error) {
+	var parity byte
+	for _, b := range []byte(text) {
+		parity ^= b
+	}
+
+	runes := []rune(text)
+	var chunks []string
+	for len(runes) > 0 {
+		end, err := largestFittingPrefix(runes, ecl, maxVersionPerSymbol)
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, string(runes[:end]))
+		runes = runes[end:]
+		if len(chunks) > maxStructuredAppendSymbols {
+			return nil, fmt.Errorf("qrcodegen: text requires more than %d structured append symbols", maxStructuredAppendSymbols)
+		}
+	}
+	if len(chunks) == 0 {
+		chunks = []string{""}
+	}
+
+	total := len(chunks)
+	result := make([]*QrCode, total)
+	for i, chunk := range chunks {
+		header := qrsegment.MakeStructuredAppend(i, total, parity)
+		segs := append([]*qrsegment.QrSegment{header}, qrsegment.MakeSegments(chunk)...)
+		qr, err := EncodeSegmentsAdvanced(segs, ecl, MinVersion, maxVersionPerSymbol, nil, false)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = qr
+	}
+	return result, nil
+}
+
+// largestFittingPrefix returns the length of the longest prefix of runes
+// whose segments, combined with a Structured Append header, fit in
+// maxVersion at ecl.
+func largestFittingPrefix(runes []rune, ecl QrCodeEcc, maxVersion Version) (int, error) {
+	headerBits := 4 + 16 // mode indicator + fixed-width structured append data
+	capacityBits := getNumDataCodewords(maxVersion, ecl) * 8
+
+	n := 0
+	for n < len(runes) {
+		segs := qrsegment.MakeSegments(string(runes[:n+1]))
+		used, ok := qrsegment.GetTotalBits(segs, maxVersion)
+		if !ok || headerBits+used > capacityBits {
+			break
+		}
+		n++
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("qrcodegen: character %q does not fit in a structured append symbol at version %d", runes[0], maxVersion)
+	}
+	return n, nil
+}
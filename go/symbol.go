@@ -0,0 +1,79 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+// Symbol is the minimal read-only view of a rendered 2D barcode that
+// render.Drive (and so every renderer built on it) needs: a square module
+// grid of a known size, with each position's color, plus enough metadata to
+// describe what was rendered. QrCode implements Symbol. render.Drive accepts
+// any Symbol, but the render package's exported renderers take a *QrCode.
+type Symbol interface {
+	// Size returns the symbol's width and height in modules. Symbols in
+	// this package are always square.
+	Size() int32
+
+	// ModuleAt returns the color of the module at the given coordinates,
+	// false for light and true for dark. Coordinates outside [0, Size())
+	// return false.
+	ModuleAt(x, y int32) bool
+
+	// Metadata returns descriptive information about the symbol, for a
+	// renderer that wants to label or log what it drew without needing to
+	// know the concrete symbology.
+	Metadata() SymbolMetadata
+}
+
+// FunctionModuleSymbol is a Symbol that can additionally report which
+// modules belong to a function pattern (finder, timing, alignment, or
+// similar) rather than to encoded data. render.Drive checks for this
+// interface to populate ModuleRenderer.Module's isFunction parameter,
+// falling back to false for a Symbol that doesn't implement it.
+type FunctionModuleSymbol interface {
+	Symbol
+
+	// IsFunctionModuleAt reports whether the module at the given
+	// coordinates belongs to a function pattern. Coordinates outside
+	// [0, Size()) return false.
+	IsFunctionModuleAt(x, y int32) bool
+}
+
+// SymbolMetadata describes a Symbol's format and how densely it encoded its
+// payload, independent of the concrete symbology.
+type SymbolMetadata struct {
+	// Format names the symbology, e.g. "QR Code".
+	Format string
+
+	// Version identifies the size/capacity class within Format (a QR
+	// Code's version number, in [1, 40]).
+	Version int32
+
+	// ErrorCorrectionLevel is the symbol's error correction level.
+	ErrorCorrectionLevel QrCodeEcc
+}
+
+// ModuleAt returns the color of the module at the given coordinates. It is
+// GetModule under the name Symbol requires.
+func (q *QrCode) ModuleAt(x, y int32) bool {
+	return q.GetModule(x, y)
+}
+
+// IsFunctionModuleAt returns whether the module at the given coordinates
+// belongs to a function pattern. It is IsFunctionModule under the name
+// FunctionModuleSymbol requires.
+func (q *QrCode) IsFunctionModuleAt(x, y int32) bool {
+	return q.IsFunctionModule(x, y)
+}
+
+// Metadata returns q's format, version, and error correction level.
+func (q *QrCode) Metadata() SymbolMetadata {
+	return SymbolMetadata{
+		Format:               "QR Code",
+		Version:              int32(q.version),
+		ErrorCorrectionLevel: q.errorCorrectionLevel,
+	}
+}
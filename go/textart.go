@@ -0,0 +1,139 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToTextArt renders this symbol as an ASCII/Unicode block-art string for
+// printing to a terminal: each module becomes two characters so it reads as
+// roughly square in a typical monospace font, a dark module as two spaces
+// and a light module as two U+2588 FULL BLOCK characters, under the
+// assumption of a dark terminal background. This is the same format printed
+// by this project's Python demo program's print_qr function.
+//
+// Panics if border is negative; use ToTextArtChecked if border isn't
+// already known to be non-negative.
+func (q *QrCode) ToTextArt(border int32) string {
+	s, err := q.ToTextArtChecked(border)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToTextArtChecked is like ToTextArt, but returns an error instead of
+// panicking if border is negative.
+func (q *QrCode) ToTextArtChecked(border int32) (string, error) {
+	if border < 0 {
+		return "", fmt.Errorf("qrcodegen: border must be non-negative, got %d", border)
+	}
+	var sb strings.Builder
+	for y := -border; y < q.size+border; y++ {
+		for x := -border; x < q.size+border; x++ {
+			if q.GetModule(x, y) {
+				sb.WriteString("  ")
+			} else {
+				sb.WriteString("██")
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String(), nil
+}
+
+// ParseTextArt parses art, in the format ToTextArt produces (or the
+// equivalent output of this project's Python demo program), back into a
+// module grid with the quiet zone stripped off, where grid[y][x] is true
+// for a dark module. border must match the quiet zone width the art was
+// rendered with.
+//
+// Blank lines are ignored. Every remaining line must have the same even
+// number of characters, each consecutive pair of characters must be two
+// spaces (dark) or two U+2588 characters (light), and there must be more
+// rows than 2*border.
+func ParseTextArt(art string, border int32) ([][]bool, error) {
+	if border < 0 {
+		return nil, fmt.Errorf("qrcodegen: border must be non-negative")
+	}
+
+	var rows [][]bool
+	for _, line := range strings.Split(strings.ReplaceAll(art, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		runes := []rune(line)
+		if len(runes)%2 != 0 {
+			return nil, fmt.Errorf("qrcodegen: text-art line has an odd number of characters")
+		}
+		row := make([]bool, len(runes)/2)
+		for i := range row {
+			a, b := runes[i*2], runes[i*2+1]
+			dark, ok := textArtModuleColor(a, b)
+			if !ok {
+				return nil, fmt.Errorf("qrcodegen: unrecognized text-art module %q", string([]rune{a, b}))
+			}
+			row[i] = dark
+		}
+		rows = append(rows, row)
+	}
+
+	heightWithBorder := int32(len(rows))
+	if heightWithBorder <= 2*border {
+		return nil, fmt.Errorf("qrcodegen: only %d rows of content, too few for a border of %d", heightWithBorder, border)
+	}
+	widthWithBorder := int32(len(rows[0]))
+	for _, row := range rows {
+		if int32(len(row)) != widthWithBorder {
+			return nil, fmt.Errorf("qrcodegen: text-art rows have inconsistent widths")
+		}
+	}
+	if widthWithBorder != heightWithBorder {
+		return nil, fmt.Errorf("qrcodegen: text-art is %d modules wide but %d modules tall", widthWithBorder, heightWithBorder)
+	}
+
+	size := heightWithBorder - 2*border
+	grid := make([][]bool, size)
+	for y := range grid {
+		grid[y] = rows[int32(y)+border][border : border+size]
+	}
+	return grid, nil
+}
+
+// textArtModuleColor interprets one module's two rendered characters,
+// reversing ToTextArt's "  " (dark) and "██" (light) convention.
+func textArtModuleColor(a, b rune) (dark bool, ok bool) {
+	if a != b {
+		return false, false
+	}
+	switch a {
+	case ' ':
+		return true, true
+	case '█':
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// DecodeTextArt parses art (in the format ToTextArt produces) with the
+// given border width and decodes the resulting symbol's payload, combining
+// ParseTextArt, DecodeModules, and DecodeText.
+func DecodeTextArt(art string, border int32) (string, error) {
+	grid, err := ParseTextArt(art, border)
+	if err != nil {
+		return "", err
+	}
+	qr, err := DecodeModules(grid)
+	if err != nil {
+		return "", err
+	}
+	return qr.DecodeText()
+}
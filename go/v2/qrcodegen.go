@@ -0,0 +1,53 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+// Package qrcodegen is a v2 adapter over github.com/nayuki/qrcodegen (v1)
+// that exposes coordinates and sizes as plain int instead of int32,
+// matching ordinary Go slice-indexing idiom instead of forcing an int32
+// conversion at every call site.
+//
+// This is a deliberately small starting surface: QrCode wraps the v1 type
+// and re-exposes Size and GetModule with int signatures, and ToPNG in this
+// package shows the same treatment applied to a renderer. Porting the rest
+// of the public API (the encoder constructors, the other render formats,
+// qrsegment, etc.) to this convention is tracked as follow-up work; v1
+// remains the stable, fully-featured import path until that's done, and
+// Unwrap lets a caller using this package still reach any v1 API this
+// package hasn't adapted yet.
+package qrcodegen
+
+import (
+	v1 "github.com/nayuki/qrcodegen"
+)
+
+// QrCode wraps a v1 *v1.QrCode, exposing Size and GetModule as plain int.
+type QrCode struct {
+	inner *v1.QrCode
+}
+
+// Wrap adapts an existing v1 QR Code to this package's int-based surface.
+func Wrap(qr *v1.QrCode) *QrCode {
+	return &QrCode{inner: qr}
+}
+
+// Unwrap returns the underlying v1 *v1.QrCode, for reaching v1 APIs this
+// package doesn't adapt.
+func (q *QrCode) Unwrap() *v1.QrCode {
+	return q.inner
+}
+
+// Size returns this QR Code's size, in the range [21, 177].
+func (q *QrCode) Size() int {
+	return int(q.inner.Size())
+}
+
+// GetModule returns the color of the module (pixel) at the given
+// coordinates, which must satisfy 0 <= x < Size() and 0 <= y < Size().
+// true means black, false means white.
+func (q *QrCode) GetModule(x, y int) bool {
+	return q.inner.GetModule(int32(x), int32(y))
+}
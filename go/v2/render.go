@@ -0,0 +1,18 @@
+/*
+ * QR Code generator library (Go)
+ *
+ * Copyright (c) Project Nayuki. (MIT License)
+ * https://www.nayuki.io/page/qr-code-generator-library
+ */
+
+package qrcodegen
+
+import (
+	"github.com/nayuki/qrcodegen/render"
+)
+
+// ToPNG rasterizes qr to PNG, like render.ToPNG, but takes scale and border
+// as plain int instead of int32.
+func ToPNG(qr *QrCode, scale, border int, opts render.PNGOptions) ([]byte, error) {
+	return render.ToPNG(qr.inner, int32(scale), int32(border), opts)
+}